@@ -0,0 +1,203 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	kcmv1beta1 "github.com/kyma-project/kyma-companion-manager/api/v1beta1"
+)
+
+// CompanionSecretAnnotation preserves the secret of the companion backend, which has no counterpart in v1beta1,
+// when a Companion CR is stored as v1beta1. It is only set if the secret differs from its default.
+const CompanionSecretAnnotation = "operator.kyma-project.io/v1alpha1-companion-secret"
+
+// compile-time check.
+var _ conversion.Convertible = &Companion{}
+
+// defaultCompanionSecret is the default of the secret of the companion backend.
+//
+//nolint:gochecknoglobals // used as constant.
+var defaultCompanionSecret = SecretSpec{Name: "companion", Namespace: "ai-core"}
+
+// ConvertTo converts this Companion CR to the hub version v1beta1.
+func (c *Companion) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*kcmv1beta1.Companion)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 Companion but got a %T", dstRaw)
+	}
+
+	src := c.DeepCopy()
+	replicas := src.Spec.Companion.Replicas
+	if replicas.Min > math.MaxInt32 || replicas.Max > math.MaxInt32 ||
+		replicas.Min < math.MinInt32 || replicas.Max < math.MinInt32 {
+		return fmt.Errorf("the replicas of the Companion CR %s/%s are out of range",
+			src.GetNamespace(), src.GetName())
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	if src.Spec.Companion.Secret != defaultCompanionSecret {
+		secret, err := json.Marshal(src.Spec.Companion.Secret)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[CompanionSecretAnnotation] = string(secret)
+	}
+
+	companion := src.Spec.Companion
+	dst.Spec = kcmv1beta1.CompanionSpec{
+		Dependencies: kcmv1beta1.Dependencies{
+			AICore:    kcmv1beta1.Dependency{Secret: kcmv1beta1.SecretReference(src.Spec.AICore.Secret)},
+			HanaCloud: kcmv1beta1.Dependency{Secret: kcmv1beta1.SecretReference(src.Spec.HanaCloud.Secret)},
+			Redis:     kcmv1beta1.Dependency{Secret: kcmv1beta1.SecretReference(src.Spec.Redis.Secret)},
+		},
+		Backend: kcmv1beta1.BackendConfig{
+			Image: (*kcmv1beta1.ImageConfig)(companion.Image),
+			Scaling: kcmv1beta1.ScalingConfig{
+				MinReplicas:                       int32(replicas.Min),
+				MaxReplicas:                       int32(replicas.Max),
+				TargetCPUUtilizationPercentage:    replicas.TargetCPUUtilizationPercentage,
+				TargetMemoryUtilizationPercentage: replicas.TargetMemoryUtilizationPercentage,
+				PodDisruptionBudget:               (*kcmv1beta1.PodDisruptionBudgetConfig)(replicas.PodDisruptionBudget),
+			},
+			Resources:              companion.Resources,
+			WritableRootFilesystem: companion.WritableRootFilesystem,
+			Scheduling: kcmv1beta1.SchedulingConfig{
+				Tolerations:               companion.Tolerations,
+				Affinity:                  companion.Affinity,
+				TopologySpreadConstraints: companion.TopologySpreadConstraints,
+				NodeSelector:              companion.NodeSelector,
+			},
+			NetworkPolicy: (*kcmv1beta1.NetworkPolicyConfig)(companion.NetworkPolicy),
+			ClusterAccess: convertReadOnlyRulesTo(companion.ClusterAccess),
+			Exposure:      convertExposureTo(companion.Exposure),
+		},
+	}
+	dst.Status = kcmv1beta1.CompanionStatus(src.Status)
+	return nil
+}
+
+// ConvertFrom converts the hub version v1beta1 to this Companion CR.
+func (c *Companion) ConvertFrom(srcRaw conversion.Hub) error {
+	hub, ok := srcRaw.(*kcmv1beta1.Companion)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 Companion but got a %T", srcRaw)
+	}
+
+	src := hub.DeepCopy()
+	c.ObjectMeta = src.ObjectMeta
+	companionSecret := defaultCompanionSecret
+	if secret, found := src.Annotations[CompanionSecretAnnotation]; found {
+		if err := json.Unmarshal([]byte(secret), &companionSecret); err != nil {
+			return fmt.Errorf("failed to parse the annotation %s of the Companion CR %s/%s: %w",
+				CompanionSecretAnnotation, src.GetNamespace(), src.GetName(), err)
+		}
+		delete(c.Annotations, CompanionSecretAnnotation)
+		if len(c.Annotations) == 0 {
+			c.Annotations = nil
+		}
+	}
+
+	backend := src.Spec.Backend
+	scaling := backend.Scaling
+	c.Spec = CompanionSpec{
+		AICore:    AICoreConfig{Secret: SecretSpec(src.Spec.Dependencies.AICore.Secret)},
+		HanaCloud: HanaConfig{Secret: SecretSpec(src.Spec.Dependencies.HanaCloud.Secret)},
+		Redis:     RedisConfig{Secret: SecretSpec(src.Spec.Dependencies.Redis.Secret)},
+		Companion: CompanionConfig{
+			Secret: companionSecret,
+			Replicas: ReplicasConfig{
+				Min:                               int(scaling.MinReplicas),
+				Max:                               int(scaling.MaxReplicas),
+				TargetCPUUtilizationPercentage:    scaling.TargetCPUUtilizationPercentage,
+				TargetMemoryUtilizationPercentage: scaling.TargetMemoryUtilizationPercentage,
+				PodDisruptionBudget:               (*PodDisruptionBudgetConfig)(scaling.PodDisruptionBudget),
+			},
+			Resources:                 backend.Resources,
+			Image:                     (*ImageConfig)(backend.Image),
+			WritableRootFilesystem:    backend.WritableRootFilesystem,
+			Tolerations:               backend.Scheduling.Tolerations,
+			Affinity:                  backend.Scheduling.Affinity,
+			TopologySpreadConstraints: backend.Scheduling.TopologySpreadConstraints,
+			NodeSelector:              backend.Scheduling.NodeSelector,
+			NetworkPolicy:             (*NetworkPolicyConfig)(backend.NetworkPolicy),
+			ClusterAccess:             convertReadOnlyRulesFrom(backend.ClusterAccess),
+			Exposure:                  convertExposureFrom(backend.Exposure),
+		},
+	}
+	c.Status = CompanionStatus(src.Status)
+	return nil
+}
+
+func convertReadOnlyRulesTo(rules []ReadOnlyRule) []kcmv1beta1.ReadOnlyRule {
+	if rules == nil {
+		return nil
+	}
+	result := make([]kcmv1beta1.ReadOnlyRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, kcmv1beta1.ReadOnlyRule(rule))
+	}
+	return result
+}
+
+func convertReadOnlyRulesFrom(rules []kcmv1beta1.ReadOnlyRule) []ReadOnlyRule {
+	if rules == nil {
+		return nil
+	}
+	result := make([]ReadOnlyRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, ReadOnlyRule(rule))
+	}
+	return result
+}
+
+func convertExposureTo(exposure *ExposureConfig) *kcmv1beta1.ExposureConfig {
+	if exposure == nil {
+		return nil
+	}
+	return &kcmv1beta1.ExposureConfig{
+		Type:    kcmv1beta1.ExposureType(exposure.Type),
+		Host:    exposure.Host,
+		Gateway: exposure.Gateway,
+		AccessStrategy: kcmv1beta1.AccessStrategy{
+			JWT:    (*kcmv1beta1.JWTAccessStrategy)(exposure.AccessStrategy.JWT),
+			OAuth2: (*kcmv1beta1.OAuth2AccessStrategy)(exposure.AccessStrategy.OAuth2),
+		},
+	}
+}
+
+func convertExposureFrom(exposure *kcmv1beta1.ExposureConfig) *ExposureConfig {
+	if exposure == nil {
+		return nil
+	}
+	return &ExposureConfig{
+		Type:    ExposureType(exposure.Type),
+		Host:    exposure.Host,
+		Gateway: exposure.Gateway,
+		AccessStrategy: AccessStrategy{
+			JWT:    (*JWTAccessStrategy)(exposure.AccessStrategy.JWT),
+			OAuth2: (*OAuth2AccessStrategy)(exposure.AccessStrategy.OAuth2),
+		},
+	}
+}
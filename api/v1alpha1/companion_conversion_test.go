@@ -0,0 +1,231 @@
+package v1alpha1
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1beta1 "github.com/kyma-project/kyma-companion-manager/api/v1beta1"
+)
+
+const fuzzIterations = 200
+
+func Test_Companion_ConvertTo(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenCompanion  *Companion
+		wantAnnotations map[string]string
+		wantSpec        kcmv1beta1.CompanionSpec
+		wantError       bool
+	}{
+		{
+			name: "should group the dependencies and backend settings and omit the default companion secret",
+			givenCompanion: &Companion{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+				Spec: CompanionSpec{
+					AICore:    AICoreConfig{Secret: SecretSpec{Name: "ai-core", Namespace: "ai-core"}},
+					HanaCloud: HanaConfig{Secret: SecretSpec{Name: "companion", Namespace: "hana-cloud"}},
+					Redis:     RedisConfig{Secret: SecretSpec{Name: "companion", Namespace: "redis"}},
+					Companion: CompanionConfig{
+						Secret:       SecretSpec{Name: "companion", Namespace: "ai-core"},
+						Replicas:     ReplicasConfig{Min: 1, Max: 3},
+						NodeSelector: map[string]string{"zone": "a"},
+					},
+				},
+			},
+			wantSpec: kcmv1beta1.CompanionSpec{
+				Dependencies: kcmv1beta1.Dependencies{
+					AICore: kcmv1beta1.Dependency{
+						Secret: kcmv1beta1.SecretReference{Name: "ai-core", Namespace: "ai-core"},
+					},
+					HanaCloud: kcmv1beta1.Dependency{
+						Secret: kcmv1beta1.SecretReference{Name: "companion", Namespace: "hana-cloud"},
+					},
+					Redis: kcmv1beta1.Dependency{
+						Secret: kcmv1beta1.SecretReference{Name: "companion", Namespace: "redis"},
+					},
+				},
+				Backend: kcmv1beta1.BackendConfig{
+					Scaling:    kcmv1beta1.ScalingConfig{MinReplicas: 1, MaxReplicas: 3},
+					Scheduling: kcmv1beta1.SchedulingConfig{NodeSelector: map[string]string{"zone": "a"}},
+				},
+			},
+		},
+		{
+			name: "should preserve a companion secret which differs from the default in an annotation",
+			givenCompanion: &Companion{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+				Spec: CompanionSpec{
+					Companion: CompanionConfig{Secret: SecretSpec{Name: "custom", Namespace: "kyma-system"}},
+				},
+			},
+			wantAnnotations: map[string]string{
+				CompanionSecretAnnotation: `{"name":"custom","namespace":"kyma-system"}`,
+			},
+		},
+		{
+			name: "should fail if the replicas do not fit into the v1beta1 scaling",
+			givenCompanion: &Companion{
+				Spec: CompanionSpec{
+					Companion: CompanionConfig{
+						Secret:   defaultCompanionSecret,
+						Replicas: ReplicasConfig{Min: 1, Max: math.MaxInt32 + 1},
+					},
+				},
+			},
+			wantError: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			hub := &kcmv1beta1.Companion{}
+
+			// when
+			err := tc.givenCompanion.ConvertTo(hub)
+
+			// then
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantAnnotations, hub.GetAnnotations())
+			require.Equal(t, tc.wantSpec, hub.Spec)
+		})
+	}
+}
+
+func Test_Companion_ConvertFrom(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenAnnotation map[string]string
+		wantAnnotations map[string]string
+		wantSecret      SecretSpec
+		wantError       bool
+	}{
+		{
+			name:       "should use the default companion secret if the annotation is not set",
+			wantSecret: defaultCompanionSecret,
+		},
+		{
+			name: "should restore the companion secret from the annotation and remove the annotation",
+			givenAnnotation: map[string]string{
+				CompanionSecretAnnotation: `{"name":"custom","namespace":"kyma-system"}`,
+				"other":                   "value",
+			},
+			wantAnnotations: map[string]string{"other": "value"},
+			wantSecret:      SecretSpec{Name: "custom", Namespace: "kyma-system"},
+		},
+		{
+			name:            "should fail if the annotation cannot be parsed",
+			givenAnnotation: map[string]string{CompanionSecretAnnotation: "custom"},
+			wantError:       true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			hub := &kcmv1beta1.Companion{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "default", Namespace: "kyma-system", Annotations: tc.givenAnnotation},
+			}
+			companion := &Companion{}
+
+			// when
+			err := companion.ConvertFrom(hub)
+
+			// then
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantAnnotations, companion.GetAnnotations())
+			require.Equal(t, tc.wantSecret, companion.Spec.Companion.Secret)
+			require.Equal(t, tc.givenAnnotation, hub.GetAnnotations(), "the hub must not be modified")
+		})
+	}
+}
+
+// Test_Companion_RoundTrip_FromV1alpha1 checks that no data of a v1alpha1 Companion CR is lost when it is
+// converted to the hub version and back.
+func Test_Companion_RoundTrip_FromV1alpha1(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := newConversionFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		// given
+		givenCompanion := &Companion{}
+		fuzzer.Fuzz(givenCompanion)
+		hub := &kcmv1beta1.Companion{}
+		gotCompanion := &Companion{}
+
+		// when
+		require.NoError(t, givenCompanion.ConvertTo(hub))
+		require.NoError(t, gotCompanion.ConvertFrom(hub))
+
+		// then
+		require.Equal(t, givenCompanion, gotCompanion)
+	}
+}
+
+// Test_Companion_RoundTrip_FromV1beta1 checks that no data of a v1beta1 Companion CR is lost when it is
+// converted to v1alpha1 and back.
+func Test_Companion_RoundTrip_FromV1beta1(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := newConversionFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		// given
+		givenHub := &kcmv1beta1.Companion{}
+		fuzzer.Fuzz(givenHub)
+		companion := &Companion{}
+		gotHub := &kcmv1beta1.Companion{}
+
+		// when
+		require.NoError(t, companion.ConvertFrom(givenHub))
+		require.NoError(t, companion.ConvertTo(gotHub))
+
+		// then
+		require.Equal(t, givenHub, gotHub)
+	}
+}
+
+// newConversionFuzzer returns a fuzzer which only generates replicas that can be represented in both versions.
+// The type meta is left empty, because it is set by the conversion webhook. The seed is logged to reproduce failures.
+func newConversionFuzzer(t *testing.T) *fuzz.Fuzzer {
+	t.Helper()
+
+	seed := rand.Int63() //nolint:gosec // the seed of a test fuzzer does not need to be secure.
+	t.Logf("fuzzer seed: %d", seed)
+	return fuzz.NewWithSeed(seed).NilChance(0.2).Funcs(
+		func(typeMeta *kmetav1.TypeMeta, _ fuzz.Continue) {
+			*typeMeta = kmetav1.TypeMeta{}
+		},
+		func(replicas *ReplicasConfig, c fuzz.Continue) {
+			c.FuzzNoCustom(replicas)
+			replicas.Min = int(c.Int31())
+			replicas.Max = int(c.Int31())
+		},
+	)
+}
@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -94,15 +96,205 @@ type CompanionConfig struct {
 	// Specify required resources and resource limits for the companion backend.
 	// +kubebuilder:default:={limits:{cpu:4,memory:"4Gi"}, requests:{cpu:"500m",memory:"256Mi"}}
 	Resources kcorev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Container image of the companion backend. If not set, the default image of the Kyma companion manager is used.
+	// +optional
+	Image *ImageConfig `json:"image,omitempty"`
+
+	// If true, the root filesystem of the companion backend container is writable.
+	// By default, it is read-only and only `/tmp` is writable.
+	// +optional
+	WritableRootFilesystem bool `json:"writableRootFilesystem,omitempty"`
+
+	// Tolerations of the companion backend pods.
+	// +optional
+	Tolerations []kcorev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the companion backend pods.
+	// +optional
+	Affinity *kcorev1.Affinity `json:"affinity,omitempty"`
+
+	// Topology spread constraints of the companion backend pods.
+	// If not set, the pods are spread across zones.
+	// +optional
+	TopologySpreadConstraints []kcorev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Node selector of the companion backend pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// NetworkPolicy which restricts the network access of the companion backend.
+	// +optional
+	NetworkPolicy *NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
+	// Resources of the cluster which the companion backend may read. The access is granted to a dedicated
+	// ServiceAccount of the companion backend, and is always limited to the get, list and watch verbs.
+	// If not set, the companion backend can read the common workload, networking and event resources, but no secrets.
+	// Wildcards, secrets and configmaps are not allowed.
+	// +optional
+	ClusterAccess []ReadOnlyRule `json:"clusterAccess,omitempty"`
+
+	// Exposure of the companion backend outside of the cluster, e.g. for the Kyma dashboard plugin.
+	// If not set, the companion backend is only reachable inside the cluster.
+	// +optional
+	Exposure *ExposureConfig `json:"exposure,omitempty"`
+}
+
+// ExposureType is the kind of resource through which the companion backend is exposed.
+type ExposureType string
+
+const (
+	// ExposureTypeAPIRule exposes the companion backend through a Kyma APIRule.
+	ExposureTypeAPIRule ExposureType = "APIRule"
+	// ExposureTypeVirtualService exposes the companion backend through an Istio VirtualService. The access
+	// strategy is enforced by an Istio RequestAuthentication and AuthorizationPolicy.
+	ExposureTypeVirtualService ExposureType = "VirtualService"
+)
+
+// ExposureConfig defines how the companion backend is exposed outside of the cluster.
+// If a NetworkPolicy is enabled, the ingress gateway must be added to its ingressFrom peers.
+type ExposureConfig struct {
+	// Kind of resource through which the companion backend is exposed. Defaults to `APIRule`.
+	// +kubebuilder:validation:Enum=APIRule;VirtualService
+	// +kubebuilder:default=APIRule
+	// +optional
+	Type ExposureType `json:"type,omitempty"`
+
+	// Fully qualified host name under which the companion backend is exposed, e.g. `companion.example.com`.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`
+	Host string `json:"host"`
+
+	// Istio gateway in the format `<namespace>/<name>`. Defaults to `kyma-system/kyma-gateway`.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9-]+/[a-z0-9-]+$`
+	// +kubebuilder:default="kyma-system/kyma-gateway"
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+
+	// Strategy which authenticates the requests to the companion backend. Exactly one strategy must be set.
+	AccessStrategy AccessStrategy `json:"accessStrategy"`
+}
+
+// AccessStrategy defines how the requests to the exposed companion backend are authenticated.
+type AccessStrategy struct {
+	// Requires a JSON Web Token issued by the given issuer.
+	// +optional
+	JWT *JWTAccessStrategy `json:"jwt,omitempty"`
+
+	// Requires an OAuth2 access token, which is validated by token introspection. It is only supported with
+	// the `APIRule` exposure type.
+	// +optional
+	OAuth2 *OAuth2AccessStrategy `json:"oauth2,omitempty"`
+}
+
+// JWTAccessStrategy defines the issuer of the JSON Web Tokens accepted by the companion backend.
+type JWTAccessStrategy struct {
+	// Issuer of the tokens, e.g. `https://example.accounts.ondemand.com`.
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// URL of the JSON Web Key Set which is used to verify the tokens.
+	// +kubebuilder:validation:Pattern=`^https://`
+	JWKSURL string `json:"jwksURL"`
+}
+
+// OAuth2AccessStrategy defines the OAuth2 access tokens accepted by the companion backend.
+type OAuth2AccessStrategy struct {
+	// Scopes which the access tokens must have.
+	// +optional
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
+}
+
+// ReadOnlyRule grants the companion backend read access to the given resources.
+type ReadOnlyRule struct {
+	// API groups of the resources. The core API group is represented by an empty string.
+	// +kubebuilder:validation:MinItems=1
+	APIGroups []string `json:"apiGroups"`
+
+	// Resources to which the read access is granted, e.g. `pods` or `deployments`.
+	// +kubebuilder:validation:MinItems=1
+	Resources []string `json:"resources"`
+}
+
+// NetworkPolicyConfig defines the NetworkPolicy of the companion backend.
+type NetworkPolicyConfig struct {
+	// If true, a NetworkPolicy is created for the companion backend. It only allows ingress from the given peers,
+	// and egress to DNS, to the HANA and Redis endpoints of the referenced secrets and to the given egress rules.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Peers from which ingress to the companion backend is allowed, e.g. the namespace of the Kyma dashboard.
+	// If empty, all ingress is denied.
+	// +optional
+	IngressFrom []knetworkingv1.NetworkPolicyPeer `json:"ingressFrom,omitempty"`
+
+	// Additional egress rules of the companion backend, e.g. to allow access to AI Core.
+	// +optional
+	Egress []knetworkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// ImageConfig defines the container image of the companion backend.
+// Fields which are not set are taken from the default image of the Kyma companion manager.
+type ImageConfig struct {
+	// Repository of the image, for example `europe-docker.pkg.dev/kyma-project/prod/kyma-companion`.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Tag of the image. It is ignored if a digest is set.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest of the image, for example `sha256:<hash>`. It takes precedence over the tag.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Pull policy of the image. Defaults to `Always`.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	PullPolicy kcorev1.PullPolicy `json:"pullPolicy,omitempty"`
 }
 
 // ReplicasConfig defines the min and max replicas.
+// The companion backend is scaled between min and max by a HorizontalPodAutoscaler.
 type ReplicasConfig struct {
 	// Minimum number of replicas for the companion backend.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
 	Min int `json:"min"`
 
 	// Maximum number of replicas for the companion backend.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
 	Max int `json:"max"`
+
+	// Target average CPU utilization, in percent of the requested CPU, at which the companion backend is scaled.
+	// If neither a CPU nor a memory target is set, a CPU target of 80 percent is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Target average memory utilization, in percent of the requested memory, at which the companion backend is scaled.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// PodDisruptionBudget of the companion backend. It is only created if max replicas is greater than 1.
+	// If not set, at most one replica may be unavailable during voluntary disruptions, e.g. node drains.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudgetConfig defines the PodDisruptionBudget of the companion backend.
+// Only one of minAvailable and maxUnavailable may be set.
+type PodDisruptionBudgetConfig struct {
+	// Number or percentage of replicas which must stay available during voluntary disruptions.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Number or percentage of replicas which may be unavailable during voluntary disruptions.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // CompanionStatus defines the observed state of Companion.
@@ -115,10 +307,33 @@ type CompanionStatus struct {
 	// - `Error` if an error occurred while reconciling the Companion custom resource.
 	// - `Deleting` if the resources managed by the Kyma companion manager are being deleted.
 	State string `json:"state"`
+
+	// The container image of the companion backend which is deployed.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// The public URL of the companion backend, if it is exposed outside of the cluster.
+	// +optional
+	PublicURL string `json:"publicURL,omitempty"`
+
+	// The generation of the Companion custom resource which was last processed by the Kyma companion manager.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions of the Companion custom resource, for example SecretsResolved, BackendSecretSynced,
+	// DeploymentAvailable and Ready.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image",priority=1
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.publicURL",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Companion is the Schema for the companions API.
 type Companion struct {
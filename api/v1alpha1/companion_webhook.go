@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	kctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// compile-time check.
+var _ webhook.CustomValidator = &CompanionValidator{}
+
+// CompanionValidator validates the Companion CRs on create and update.
+// +kubebuilder:object:generate=false
+type CompanionValidator struct {
+	reader client.Reader
+}
+
+// NewCompanionValidator returns a validator which uses the given reader to look up the existing Companion CRs.
+func NewCompanionValidator(reader client.Reader) *CompanionValidator {
+	return &CompanionValidator{reader: reader}
+}
+
+// SetupWebhookWithManager registers the validating webhook for the Companion CR in the manager.
+func (r *Companion) SetupWebhookWithManager(mgr kctrl.Manager) error {
+	return kctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(NewCompanionValidator(mgr.GetAPIReader())).
+		Complete()
+}
+
+//nolint:lll // ignore long line length due to kubebuilder markers.
+// +kubebuilder:webhook:path=/validate-operator-kyma-project-io-v1alpha1-companion,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.kyma-project.io,resources=companions,verbs=create;update,versions=v1alpha1,name=vcompanion.kb.io,admissionReviewVersions=v1
+
+// ValidateCreate rejects an invalid spec and a second Companion CR in the cluster.
+func (v *CompanionValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	companion, ok := obj.(*Companion)
+	if !ok {
+		return nil, fmt.Errorf("expected a Companion but got a %T", obj)
+	}
+
+	if err := validateCompanion(companion); err != nil {
+		return nil, err
+	}
+
+	// only a single Companion CR is allowed in the cluster.
+	companions := &CompanionList{}
+	if err := v.reader.List(ctx, companions); err != nil {
+		return nil, err
+	}
+	for _, existing := range companions.Items {
+		if existing.GetName() == companion.GetName() && existing.GetNamespace() == companion.GetNamespace() {
+			continue
+		}
+		return nil, kapierrors.NewForbidden(GroupVersion.WithResource("companions").GroupResource(),
+			companion.GetName(), fmt.Errorf("only one Companion CR is allowed in the cluster, "+
+				"but %s/%s already exists", existing.GetNamespace(), existing.GetName()))
+	}
+	return nil, nil
+}
+
+// ValidateUpdate rejects an invalid spec. Updates of a Companion CR in deletion and updates which change neither
+// the spec nor the annotations are allowed, so that the finalizer can be added and removed even if the CR is invalid.
+func (v *CompanionValidator) ValidateUpdate(_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	companion, ok := newObj.(*Companion)
+	if !ok {
+		return nil, fmt.Errorf("expected a Companion but got a %T", newObj)
+	}
+	oldCompanion, ok := oldObj.(*Companion)
+	if !ok {
+		return nil, fmt.Errorf("expected a Companion but got a %T", oldObj)
+	}
+
+	if companion.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	if equality.Semantic.DeepEqual(oldCompanion.Spec, companion.Spec) &&
+		equality.Semantic.DeepEqual(oldCompanion.GetAnnotations(), companion.GetAnnotations()) {
+		return nil, nil
+	}
+	return nil, validateCompanion(companion)
+}
+
+// ValidateDelete allows the deletion of all Companion CRs.
+func (v *CompanionValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateCompanion returns an Invalid error if the spec or the annotations of the given Companion CR are invalid.
+func validateCompanion(companion *Companion) error {
+	errs := append(companion.ValidateSpec(), companion.ValidateAnnotations()...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return kapierrors.NewInvalid(GroupVersion.WithKind("Companion").GroupKind(), companion.GetName(), errs)
+}
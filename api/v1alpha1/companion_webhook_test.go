@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_ValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenExisting []client.Object
+		givenModifier func(companion *Companion)
+		wantInvalid   bool
+		wantForbidden bool
+		wantAllowed   bool
+	}{
+		{
+			name:          "should allow a valid Companion CR",
+			givenModifier: func(_ *Companion) {},
+			wantAllowed:   true,
+		},
+		{
+			name: "should reject an invalid spec",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Replicas = ReplicasConfig{Min: 3, Max: 1}
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "should reject a second Companion CR in the cluster",
+			givenExisting: []client.Object{
+				&Companion{ObjectMeta: kmetav1.ObjectMeta{Name: "existing", Namespace: "other"}},
+			},
+			givenModifier: func(_ *Companion) {},
+			wantForbidden: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			validator := newTestValidator(t, tc.givenExisting...)
+			companion := newValidCompanion()
+			companion.ObjectMeta = kmetav1.ObjectMeta{Name: "companion", Namespace: "kyma-system"}
+			tc.givenModifier(companion)
+
+			// when
+			_, err := validator.ValidateCreate(context.Background(), companion)
+
+			// then
+			if tc.wantAllowed {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantInvalid, kapierrors.IsInvalid(err))
+			require.Equal(t, tc.wantForbidden, kapierrors.IsForbidden(err))
+		})
+	}
+}
+
+func Test_ValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name           string
+		givenOld       func(companion *Companion)
+		givenModifier  func(companion *Companion)
+		wantErrorField string
+	}{
+		{
+			name:          "should allow a valid update",
+			givenOld:      func(_ *Companion) {},
+			givenModifier: func(_ *Companion) {},
+		},
+		{
+			name:     "should reject an invalid spec",
+			givenOld: func(_ *Companion) {},
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Redis.Secret.Name = ""
+			},
+			wantErrorField: "spec.redis.secret.name",
+		},
+		{
+			name:     "should reject an invalid reconcile annotation",
+			givenOld: func(_ *Companion) {},
+			givenModifier: func(companion *Companion) {
+				companion.SetAnnotations(map[string]string{ReconcileAnnotation: "pause"})
+			},
+			wantErrorField: ReconcileAnnotation,
+		},
+		{
+			name: "should allow removing the finalizer from an invalid Companion CR",
+			givenOld: func(companion *Companion) {
+				companion.Spec.Redis.Secret.Name = ""
+				companion.SetFinalizers([]string{"test-finalizer"})
+			},
+			givenModifier: func(companion *Companion) {
+				companion.SetFinalizers(nil)
+			},
+		},
+		{
+			name: "should allow updating an invalid Companion CR in deletion",
+			givenOld: func(companion *Companion) {
+				companion.Spec.Redis.Secret.Name = ""
+			},
+			givenModifier: func(companion *Companion) {
+				companion.SetDeletionTimestamp(&kmetav1.Time{Time: time.Now()})
+				companion.Spec.Companion.Replicas = ReplicasConfig{Min: 3, Max: 1}
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			oldCompanion := newValidCompanion()
+			oldCompanion.ObjectMeta = kmetav1.ObjectMeta{Name: "companion", Namespace: "kyma-system"}
+			tc.givenOld(oldCompanion)
+			newCompanion := oldCompanion.DeepCopy()
+			tc.givenModifier(newCompanion)
+			validator := newTestValidator(t, oldCompanion.DeepCopy())
+
+			// when
+			_, err := validator.ValidateUpdate(context.Background(), oldCompanion, newCompanion)
+
+			// then
+			if tc.wantErrorField == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, kapierrors.IsInvalid(err))
+			require.Contains(t, err.Error(), tc.wantErrorField)
+		})
+	}
+}
+
+func newTestValidator(t *testing.T, objs ...client.Object) *CompanionValidator {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	return NewCompanionValidator(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
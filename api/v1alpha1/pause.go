@@ -0,0 +1,57 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+)
+
+const (
+	// ReconcileAnnotation controls the reconciliation of the Companion CR. If it is set to ReconcilePaused,
+	// the resources of the companion backend are not changed, e.g. to keep manual edits during an incident.
+	// The status of the Companion CR is still updated, and its deletion is not paused.
+	ReconcileAnnotation = "operator.kyma-project.io/reconcile"
+
+	// ReconcilePausedUntilAnnotation optionally ends the pause of the reconciliation at the given RFC 3339
+	// timestamp, e.g. `2024-06-01T18:00:00Z`.
+	ReconcilePausedUntilAnnotation = "operator.kyma-project.io/reconcile-paused-until"
+
+	// ReconcilePaused is the value of the ReconcileAnnotation which pauses the reconciliation.
+	ReconcilePaused = "paused"
+)
+
+// GetReconcilePause returns true if the reconciliation of the Companion CR is paused at the given time.
+// If the pause has an expiry, it is returned as well. An expiry which cannot be parsed is ignored,
+// as it is rejected by ValidateAnnotations.
+func (c *Companion) GetReconcilePause(now time.Time) (bool, *time.Time) {
+	if c.GetAnnotations()[ReconcileAnnotation] != ReconcilePaused {
+		return false, nil
+	}
+
+	value, found := c.GetAnnotations()[ReconcilePausedUntilAnnotation]
+	if !found {
+		return true, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return true, nil
+	}
+	if !now.Before(until) {
+		return false, nil
+	}
+	return true, &until
+}
@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_GetReconcilePause(t *testing.T) {
+	t.Parallel()
+
+	givenNow := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	givenUntil := givenNow.Add(time.Hour)
+
+	// define test cases
+	testCases := []struct {
+		name             string
+		givenAnnotations map[string]string
+		wantPaused       bool
+		wantUntil        *time.Time
+	}{
+		{
+			name:       "should not be paused without annotations",
+			wantPaused: false,
+		},
+		{
+			name:             "should be paused without expiry",
+			givenAnnotations: map[string]string{ReconcileAnnotation: ReconcilePaused},
+			wantPaused:       true,
+		},
+		{
+			name: "should be paused until the expiry",
+			givenAnnotations: map[string]string{
+				ReconcileAnnotation:            ReconcilePaused,
+				ReconcilePausedUntilAnnotation: givenUntil.Format(time.RFC3339),
+			},
+			wantPaused: true,
+			wantUntil:  &givenUntil,
+		},
+		{
+			name: "should not be paused when the expiry has passed",
+			givenAnnotations: map[string]string{
+				ReconcileAnnotation:            ReconcilePaused,
+				ReconcilePausedUntilAnnotation: givenNow.Format(time.RFC3339),
+			},
+			wantPaused: false,
+		},
+		{
+			name: "should be paused without expiry when the expiry cannot be parsed",
+			givenAnnotations: map[string]string{
+				ReconcileAnnotation:            ReconcilePaused,
+				ReconcilePausedUntilAnnotation: "tomorrow",
+			},
+			wantPaused: true,
+		},
+		{
+			name: "should not be paused by the expiry alone",
+			givenAnnotations: map[string]string{
+				ReconcilePausedUntilAnnotation: givenUntil.Format(time.RFC3339),
+			},
+			wantPaused: false,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			companion := &Companion{ObjectMeta: kmetav1.ObjectMeta{Annotations: tc.givenAnnotations}}
+
+			// when
+			gotPaused, gotUntil := companion.GetReconcilePause(givenNow)
+
+			// then
+			require.Equal(t, tc.wantPaused, gotPaused)
+			if tc.wantUntil == nil {
+				require.Nil(t, gotUntil)
+			} else {
+				require.NotNil(t, gotUntil)
+				require.True(t, tc.wantUntil.Equal(*gotUntil))
+			}
+		})
+	}
+}
+
+func Test_ValidateAnnotations(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name             string
+		givenAnnotations map[string]string
+		wantErrorField   []string
+	}{
+		{
+			name: "should be valid without annotations",
+		},
+		{
+			name: "should be valid with a pause and an expiry",
+			givenAnnotations: map[string]string{
+				ReconcileAnnotation:            ReconcilePaused,
+				ReconcilePausedUntilAnnotation: "2024-06-01T18:00:00+02:00",
+			},
+		},
+		{
+			name:             "should be invalid with an unsupported reconcile value",
+			givenAnnotations: map[string]string{ReconcileAnnotation: "pause"},
+			wantErrorField:   []string{"metadata.annotations[operator.kyma-project.io/reconcile]"},
+		},
+		{
+			name: "should be invalid with an expiry which is not an RFC 3339 timestamp",
+			givenAnnotations: map[string]string{
+				ReconcileAnnotation:            ReconcilePaused,
+				ReconcilePausedUntilAnnotation: "2024-06-01 18:00",
+			},
+			wantErrorField: []string{"metadata.annotations[operator.kyma-project.io/reconcile-paused-until]"},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			companion := newValidCompanion()
+			companion.SetAnnotations(tc.givenAnnotations)
+
+			// when
+			errs := companion.ValidateAnnotations()
+
+			// then
+			gotErrorFields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				gotErrorFields = append(gotErrorFields, err.Field)
+			}
+			require.ElementsMatch(t, tc.wantErrorField, gotErrorFields)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types of the Companion CR.
+const (
+	ConditionTypeActive                 string = "Active"
+	ConditionTypeSpecValid              string = "SpecValid"
+	ConditionTypeSecretsResolved        string = "SecretsResolved"
+	ConditionTypeBackendSecretSynced    string = "BackendSecretSynced"
+	ConditionTypeDeploymentAvailable    string = "DeploymentAvailable"
+	ConditionTypeBackendResourcesSynced string = "BackendResourcesSynced"
+	ConditionTypeReady                  string = "Ready"
+	// ConditionTypePaused is only set while the reconciliation is paused by the ReconcileAnnotation.
+	// It does not affect the readiness of the Companion CR.
+	ConditionTypePaused string = "Paused"
+)
+
+// Condition reasons of the Companion CR.
+const (
+	ConditionReasonActive                     string = "Active"
+	ConditionReasonDuplicateCompanion         string = "DuplicateCompanion"
+	ConditionReasonSpecValid                  string = "SpecValid"
+	ConditionReasonSpecInvalid                string = "SpecInvalid"
+	ConditionReasonSecretsResolved            string = "SecretsResolved"
+	ConditionReasonSecretNotFound             string = "SecretNotFound"
+	ConditionReasonSecretInvalid              string = "SecretInvalid"
+	ConditionReasonSecretsResolveFailed       string = "SecretsResolveFailed"
+	ConditionReasonBackendSecretSynced        string = "BackendSecretSynced"
+	ConditionReasonBackendSecretSyncFailed    string = "BackendSecretSyncFailed"
+	ConditionReasonDeploymentAvailable        string = "DeploymentAvailable"
+	ConditionReasonDeploymentNotAvailable     string = "DeploymentNotAvailable"
+	ConditionReasonDeploymentSyncFailed       string = "DeploymentSyncFailed"
+	ConditionReasonDeploymentRolloutFailed    string = "DeploymentRolloutFailed"
+	ConditionReasonBackendPodsFailed          string = "BackendPodsFailed"
+	ConditionReasonBackendResourcesSynced     string = "BackendResourcesSynced"
+	ConditionReasonBackendResourcesSyncFailed string = "BackendResourcesSyncFailed"
+	ConditionReasonReady                      string = "Ready"
+	ConditionReasonProcessing                 string = "Processing"
+	ConditionReasonDeleting                   string = "Deleting"
+	ConditionReasonReconcilePaused            string = "ReconcilePaused"
+)
+
+const ConditionMessageReady = "Kyma companion backend is ready."
+
+// readinessConditionTypes are the condition types which define the readiness of the Companion CR.
+//
+//nolint:gochecknoglobals // used as constant.
+var readinessConditionTypes = []string{
+	ConditionTypeActive,
+	ConditionTypeSpecValid,
+	ConditionTypeSecretsResolved,
+	ConditionTypeBackendSecretSynced,
+	ConditionTypeDeploymentAvailable,
+	ConditionTypeBackendResourcesSynced,
+}
+
+// warningReasons are the condition reasons which are caused by a user input misconfiguration.
+//
+//nolint:gochecknoglobals // used as constant.
+var warningReasons = map[string]bool{
+	ConditionReasonDuplicateCompanion: true,
+	ConditionReasonSpecInvalid:        true,
+	ConditionReasonSecretNotFound:     true,
+	ConditionReasonSecretInvalid:      true,
+}
+
+// processingReasons are the condition reasons which are expected to resolve without user interaction.
+//
+//nolint:gochecknoglobals // used as constant.
+var processingReasons = map[string]bool{
+	ConditionReasonDeploymentNotAvailable: true,
+}
+
+// statePriority defines which state wins if the conditions result in different states.
+//
+//nolint:gochecknoglobals // used as constant.
+var statePriority = map[string]int{
+	StateReady:      0,
+	StateProcessing: 1,
+	StateWarning:    2,
+	StateError:      3,
+}
+
+// SetCondition adds or updates the given condition in the status of the Companion CR.
+// The LastTransitionTime is only updated if the status of the condition has changed.
+func (c *Companion) SetCondition(conditionType string, status kmetav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&c.Status.Conditions, kmetav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// UpdateStateFromConditions derives the State and the Ready condition of the Companion CR from
+// its other conditions. It also sets the observedGeneration to the current generation of the CR.
+func (c *Companion) UpdateStateFromConditions() {
+	state := StateReady
+	var notReadyCondition *kmetav1.Condition
+	for _, conditionType := range readinessConditionTypes {
+		condition := meta.FindStatusCondition(c.Status.Conditions, conditionType)
+		conditionState := stateForCondition(condition)
+		if statePriority[conditionState] > statePriority[state] {
+			state = conditionState
+			notReadyCondition = condition
+		}
+	}
+
+	c.Status.State = state
+	c.Status.ObservedGeneration = c.GetGeneration()
+
+	switch {
+	case state == StateReady:
+		c.SetCondition(ConditionTypeReady, kmetav1.ConditionTrue, ConditionReasonReady, ConditionMessageReady)
+	case notReadyCondition == nil:
+		c.SetCondition(ConditionTypeReady, kmetav1.ConditionFalse, ConditionReasonProcessing,
+			"Waiting for the reconciliation of the Kyma companion backend.")
+	default:
+		c.SetCondition(ConditionTypeReady, kmetav1.ConditionFalse, notReadyCondition.Reason,
+			notReadyCondition.Message)
+	}
+}
+
+// SetStateDeleting sets the Deleting state of the Companion CR. The given message is set in the Ready condition
+// to show the progress of the deletion.
+func (c *Companion) SetStateDeleting(message string) {
+	c.Status.State = StateDeleting
+	c.Status.ObservedGeneration = c.GetGeneration()
+	c.SetCondition(ConditionTypeReady, kmetav1.ConditionFalse, ConditionReasonDeleting, message)
+}
+
+// IsEqual returns true if the given status is equal to the current one.
+// The LastTransitionTime of the conditions is ignored.
+func (cs CompanionStatus) IsEqual(status CompanionStatus) bool {
+	if cs.State != status.State || cs.ObservedGeneration != status.ObservedGeneration || cs.Image != status.Image ||
+		cs.PublicURL != status.PublicURL {
+		return false
+	}
+	if len(cs.Conditions) != len(status.Conditions) {
+		return false
+	}
+	for _, condition := range cs.Conditions {
+		other := meta.FindStatusCondition(status.Conditions, condition.Type)
+		if other == nil || !conditionEqual(condition, *other) {
+			return false
+		}
+	}
+	return true
+}
+
+func conditionEqual(a, b kmetav1.Condition) bool {
+	return a.Type == b.Type &&
+		a.Status == b.Status &&
+		a.Reason == b.Reason &&
+		a.Message == b.Message &&
+		a.ObservedGeneration == b.ObservedGeneration
+}
+
+// stateForCondition returns the state which is represented by the given condition.
+func stateForCondition(condition *kmetav1.Condition) string {
+	if condition == nil {
+		return StateProcessing
+	}
+	switch condition.Status {
+	case kmetav1.ConditionTrue:
+		return StateReady
+	case kmetav1.ConditionFalse:
+		if warningReasons[condition.Reason] {
+			return StateWarning
+		}
+		if processingReasons[condition.Reason] {
+			return StateProcessing
+		}
+		return StateError
+	default:
+		return StateProcessing
+	}
+}
@@ -0,0 +1,273 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_UpdateStateFromConditions(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenConditions []kmetav1.Condition
+		wantState       string
+		wantReadyStatus kmetav1.ConditionStatus
+		wantReadyReason string
+	}{
+		{
+			name:            "should be processing when no condition is set",
+			wantState:       StateProcessing,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonProcessing,
+		},
+		{
+			name: "should be processing when the deployment is not yet available",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeActive, Status: kmetav1.ConditionTrue, Reason: ConditionReasonActive},
+				{Type: ConditionTypeSpecValid, Status: kmetav1.ConditionTrue, Reason: ConditionReasonSpecValid},
+				{Type: ConditionTypeSecretsResolved, Status: kmetav1.ConditionTrue, Reason: ConditionReasonSecretsResolved},
+				{
+					Type: ConditionTypeBackendSecretSynced, Status: kmetav1.ConditionTrue,
+					Reason: ConditionReasonBackendSecretSynced,
+				},
+				{
+					Type: ConditionTypeDeploymentAvailable, Status: kmetav1.ConditionFalse,
+					Reason: ConditionReasonDeploymentNotAvailable,
+				},
+			},
+			wantState:       StateProcessing,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonDeploymentNotAvailable,
+		},
+		{
+			name: "should be error when the pods of the deployment are failing",
+			givenConditions: []kmetav1.Condition{
+				{
+					Type: ConditionTypeDeploymentAvailable, Status: kmetav1.ConditionFalse,
+					Reason: ConditionReasonBackendPodsFailed,
+				},
+			},
+			wantState:       StateError,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonBackendPodsFailed,
+		},
+		{
+			name: "should be warning when a referenced secret is missing",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeSecretsResolved, Status: kmetav1.ConditionFalse, Reason: ConditionReasonSecretNotFound},
+			},
+			wantState:       StateWarning,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonSecretNotFound,
+		},
+		{
+			name: "should be warning when a referenced secret is invalid",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeSecretsResolved, Status: kmetav1.ConditionFalse, Reason: ConditionReasonSecretInvalid},
+			},
+			wantState:       StateWarning,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonSecretInvalid,
+		},
+		{
+			name: "should be error when error and warning conditions are set",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeSecretsResolved, Status: kmetav1.ConditionFalse, Reason: ConditionReasonSecretNotFound},
+				{
+					Type: ConditionTypeDeploymentAvailable, Status: kmetav1.ConditionFalse,
+					Reason: ConditionReasonDeploymentSyncFailed,
+				},
+			},
+			wantState:       StateError,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonDeploymentSyncFailed,
+		},
+		{
+			name: "should be warning when the Companion CR is not the active one",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeActive, Status: kmetav1.ConditionFalse, Reason: ConditionReasonDuplicateCompanion},
+			},
+			wantState:       StateWarning,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonDuplicateCompanion,
+		},
+		{
+			name: "should be warning when the spec is invalid",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeSpecValid, Status: kmetav1.ConditionFalse, Reason: ConditionReasonSpecInvalid},
+			},
+			wantState:       StateWarning,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: ConditionReasonSpecInvalid,
+		},
+		{
+			name: "should be ready when all conditions are true",
+			givenConditions: []kmetav1.Condition{
+				{Type: ConditionTypeActive, Status: kmetav1.ConditionTrue, Reason: ConditionReasonActive},
+				{Type: ConditionTypeSpecValid, Status: kmetav1.ConditionTrue, Reason: ConditionReasonSpecValid},
+				{Type: ConditionTypeSecretsResolved, Status: kmetav1.ConditionTrue, Reason: ConditionReasonSecretsResolved},
+				{
+					Type: ConditionTypeBackendSecretSynced, Status: kmetav1.ConditionTrue,
+					Reason: ConditionReasonBackendSecretSynced,
+				},
+				{
+					Type: ConditionTypeDeploymentAvailable, Status: kmetav1.ConditionTrue,
+					Reason: ConditionReasonDeploymentAvailable,
+				},
+				{
+					Type: ConditionTypeBackendResourcesSynced, Status: kmetav1.ConditionTrue,
+					Reason: ConditionReasonBackendResourcesSynced,
+				},
+			},
+			wantState:       StateReady,
+			wantReadyStatus: kmetav1.ConditionTrue,
+			wantReadyReason: ConditionReasonReady,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			companion := &Companion{ObjectMeta: kmetav1.ObjectMeta{Generation: 2}}
+			for _, condition := range tc.givenConditions {
+				companion.SetCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+
+			// when
+			companion.UpdateStateFromConditions()
+
+			// then
+			require.Equal(t, tc.wantState, companion.Status.State)
+			require.Equal(t, int64(2), companion.Status.ObservedGeneration)
+			gotReadyCondition := meta.FindStatusCondition(companion.Status.Conditions, ConditionTypeReady)
+			require.NotNil(t, gotReadyCondition)
+			require.Equal(t, tc.wantReadyStatus, gotReadyCondition.Status)
+			require.Equal(t, tc.wantReadyReason, gotReadyCondition.Reason)
+			require.Equal(t, int64(2), gotReadyCondition.ObservedGeneration)
+		})
+	}
+}
+
+func Test_IsEqual(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenStatus := CompanionStatus{
+		State:              StateReady,
+		ObservedGeneration: 1,
+		Conditions: []kmetav1.Condition{
+			{
+				Type:               ConditionTypeReady,
+				Status:             kmetav1.ConditionTrue,
+				Reason:             ConditionReasonReady,
+				LastTransitionTime: kmetav1.Now(),
+			},
+		},
+	}
+
+	// define test cases
+	testCases := []struct {
+		name        string
+		givenChange func(status *CompanionStatus)
+		wantResult  bool
+	}{
+		{
+			name:        "should be equal when nothing has changed",
+			givenChange: func(_ *CompanionStatus) {},
+			wantResult:  true,
+		},
+		{
+			name: "should be equal when only the last transition time has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.Conditions[0].LastTransitionTime = kmetav1.Unix(0, 0)
+			},
+			wantResult: true,
+		},
+		{
+			name: "should not be equal when the state has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.State = StateError
+			},
+			wantResult: false,
+		},
+		{
+			name: "should not be equal when the observed generation has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.ObservedGeneration = 2
+			},
+			wantResult: false,
+		},
+		{
+			name: "should not be equal when the image has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.Image = "kyma-companion:2.0.0"
+			},
+			wantResult: false,
+		},
+		{
+			name: "should not be equal when the public URL has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.PublicURL = "https://companion.example.com"
+			},
+			wantResult: false,
+		},
+		{
+			name: "should not be equal when a condition has changed",
+			givenChange: func(status *CompanionStatus) {
+				status.Conditions[0].Status = kmetav1.ConditionFalse
+			},
+			wantResult: false,
+		},
+		{
+			name: "should not be equal when a condition was added",
+			givenChange: func(status *CompanionStatus) {
+				status.Conditions = append(status.Conditions, kmetav1.Condition{Type: ConditionTypeSecretsResolved})
+			},
+			wantResult: false,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			changedStatus := givenStatus.DeepCopy()
+			tc.givenChange(changedStatus)
+
+			// when, then
+			require.Equal(t, tc.wantResult, givenStatus.IsEqual(*changedStatus))
+		})
+	}
+}
+
+func Test_SetStateDeleting(t *testing.T) {
+	t.Parallel()
+
+	// given
+	companion := &Companion{ObjectMeta: kmetav1.ObjectMeta{Generation: 3}}
+	companion.SetCondition(ConditionTypeReady, kmetav1.ConditionTrue, ConditionReasonReady, ConditionMessageReady)
+
+	// when
+	companion.SetStateDeleting("Waiting for the deletion of: Deployment test/test.")
+
+	// then
+	require.Equal(t, StateDeleting, companion.Status.State)
+	require.Equal(t, int64(3), companion.Status.ObservedGeneration)
+	readyCondition := meta.FindStatusCondition(companion.Status.Conditions, ConditionTypeReady)
+	require.NotNil(t, readyCondition)
+	require.Equal(t, kmetav1.ConditionFalse, readyCondition.Status)
+	require.Equal(t, ConditionReasonDeleting, readyCondition.Reason)
+	require.Equal(t, "Waiting for the deletion of: Deployment test/test.", readyCondition.Message)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// wildcard matches all API groups or resources in RBAC rules.
+const wildcard = "*"
+
+// ValidateSpec validates the spec of the Companion CR for misconfigurations
+// which cannot be expressed by the OpenAPI schema of the CRD.
+func (c *Companion) ValidateSpec() field.ErrorList {
+	specPath := field.NewPath("spec")
+	companionPath := specPath.Child("companion")
+
+	var errs field.ErrorList
+	errs = append(errs, validateSecretSpec(c.Spec.AICore.Secret, specPath.Child("aicore", "secret"))...)
+	errs = append(errs, validateSecretSpec(c.Spec.HanaCloud.Secret, specPath.Child("hanaCloud", "secret"))...)
+	errs = append(errs, validateSecretSpec(c.Spec.Redis.Secret, specPath.Child("redis", "secret"))...)
+	errs = append(errs, validateSecretSpec(c.Spec.Companion.Secret, companionPath.Child("secret"))...)
+	errs = append(errs, validateReplicas(c.Spec.Companion.Replicas, companionPath.Child("replicas"))...)
+	errs = append(errs, validateResources(c.Spec.Companion.Resources, companionPath.Child("resources"))...)
+	errs = append(errs, validateExposure(c.Spec.Companion.Exposure, companionPath.Child("exposure"))...)
+	errs = append(errs, validateClusterAccess(c.Spec.Companion.ClusterAccess, companionPath.Child("clusterAccess"))...)
+	return errs
+}
+
+// ValidateAnnotations validates the annotations which control the reconciliation of the Companion CR.
+func (c *Companion) ValidateAnnotations() field.ErrorList {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	annotations := c.GetAnnotations()
+
+	var errs field.ErrorList
+	if value, found := annotations[ReconcileAnnotation]; found && value != ReconcilePaused {
+		errs = append(errs, field.NotSupported(annotationsPath.Key(ReconcileAnnotation), value,
+			[]string{ReconcilePaused}))
+	}
+	if value, found := annotations[ReconcilePausedUntilAnnotation]; found {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			errs = append(errs, field.Invalid(annotationsPath.Key(ReconcilePausedUntilAnnotation), value,
+				"must be an RFC 3339 timestamp, e.g. 2024-06-01T18:00:00Z"))
+		}
+	}
+	return errs
+}
+
+// validateSecretSpec checks that the name and the namespace of the secret are set.
+func validateSecretSpec(secret SecretSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if secret.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "secret name must not be empty"))
+	}
+	if secret.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), "secret namespace must not be empty"))
+	}
+	return errs
+}
+
+// validateReplicas checks that the min replicas are not greater than the max replicas.
+// It also checks that the PodDisruptionBudget does not set both minAvailable and maxUnavailable.
+func validateReplicas(replicas ReplicasConfig, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if replicas.Min > replicas.Max {
+		errs = append(errs, field.Invalid(path.Child("min"), replicas.Min,
+			fmt.Sprintf("must be less than or equal to max replicas of %d", replicas.Max)))
+	}
+
+	pdb := replicas.PodDisruptionBudget
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		errs = append(errs, field.Invalid(path.Child("podDisruptionBudget", "maxUnavailable"),
+			pdb.MaxUnavailable.String(), "must not be set together with minAvailable"))
+	}
+	return errs
+}
+
+// validateResources checks that no resource request is larger than the limit of the same resource.
+func validateResources(resources kcorev1.ResourceRequirements, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for name, request := range resources.Requests {
+		limit, ok := resources.Limits[name]
+		if !ok || request.Cmp(limit) <= 0 {
+			continue
+		}
+		errs = append(errs, field.Invalid(path.Child("requests").Key(string(name)), request.String(),
+			fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+	}
+	return errs
+}
+
+// validateExposure checks that exactly one access strategy is set, and that it is supported by the exposure type.
+func validateExposure(exposure *ExposureConfig, path *field.Path) field.ErrorList {
+	if exposure == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	strategyPath := path.Child("accessStrategy")
+	strategy := exposure.AccessStrategy
+	switch {
+	case strategy.JWT == nil && strategy.OAuth2 == nil:
+		errs = append(errs, field.Required(strategyPath, "one of jwt and oauth2 must be set"))
+	case strategy.JWT != nil && strategy.OAuth2 != nil:
+		errs = append(errs, field.Invalid(strategyPath.Child("oauth2"), "",
+			"must not be set together with jwt"))
+	case strategy.OAuth2 != nil && exposure.Type == ExposureTypeVirtualService:
+		errs = append(errs, field.Invalid(strategyPath.Child("oauth2"), "",
+			fmt.Sprintf("is not supported with the %s exposure type", ExposureTypeVirtualService)))
+	}
+	return errs
+}
+
+// validateClusterAccess checks that the read access of the companion backend neither uses wildcards, nor includes
+// secrets and configmaps, as they may contain credentials.
+func validateClusterAccess(clusterAccess []ReadOnlyRule, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, rule := range clusterAccess {
+		rulePath := path.Index(i)
+		for j, apiGroup := range rule.APIGroups {
+			if apiGroup == wildcard {
+				errs = append(errs, field.Invalid(rulePath.Child("apiGroups").Index(j), apiGroup,
+					"wildcards are not allowed"))
+			}
+		}
+		for j, resource := range rule.Resources {
+			// the access to a subresource, e.g. secrets/status, is checked by its resource.
+			name, _, _ := strings.Cut(resource, "/")
+			switch name {
+			case wildcard:
+				errs = append(errs, field.Invalid(rulePath.Child("resources").Index(j), resource,
+					"wildcards are not allowed"))
+			case "secrets", "configmaps":
+				errs = append(errs, field.Forbidden(rulePath.Child("resources").Index(j),
+					fmt.Sprintf("read access to %s is not allowed, as they may contain credentials", name)))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,283 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func Test_ValidateSpec_Resources(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name           string
+		givenResources kcorev1.ResourceRequirements
+		wantErrorCount int
+	}{
+		{
+			name:           "should be valid when no resources are set",
+			wantErrorCount: 0,
+		},
+		{
+			name: "should be valid when requests are lower than limits",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("500m"),
+					kcorev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("4"),
+					kcorev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			wantErrorCount: 0,
+		},
+		{
+			name: "should be valid when requests are equal to limits",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{kcorev1.ResourceCPU: resource.MustParse("1")},
+				Limits:   kcorev1.ResourceList{kcorev1.ResourceCPU: resource.MustParse("1000m")},
+			},
+			wantErrorCount: 0,
+		},
+		{
+			name: "should be valid when only requests are set",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{kcorev1.ResourceCPU: resource.MustParse("8")},
+			},
+			wantErrorCount: 0,
+		},
+		{
+			name: "should be invalid when a request is larger than its limit",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("2"),
+					kcorev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("1"),
+					kcorev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			wantErrorCount: 1,
+		},
+		{
+			name: "should be invalid when all requests are larger than their limits",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("2"),
+					kcorev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("1"),
+					kcorev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			wantErrorCount: 2,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			companion := newValidCompanion()
+			companion.Spec.Companion.Resources = tc.givenResources
+
+			// when
+			errs := companion.ValidateSpec()
+
+			// then
+			require.Len(t, errs, tc.wantErrorCount)
+		})
+	}
+}
+
+func Test_ValidateSpec(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name           string
+		givenModifier  func(companion *Companion)
+		wantErrorField []string
+	}{
+		{
+			name:          "should be valid with the default spec",
+			givenModifier: func(_ *Companion) {},
+		},
+		{
+			name: "should be valid when min replicas are equal to max replicas",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Replicas = ReplicasConfig{Min: 2, Max: 2}
+			},
+		},
+		{
+			name: "should be invalid when min replicas are greater than max replicas",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Replicas = ReplicasConfig{Min: 4, Max: 2}
+			},
+			wantErrorField: []string{"spec.companion.replicas.min"},
+		},
+		{
+			name: "should be valid when only minAvailable of the PodDisruptionBudget is set",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Replicas.PodDisruptionBudget = &PodDisruptionBudgetConfig{
+					MinAvailable: ptr.To(intstr.FromString("50%")),
+				}
+			},
+		},
+		{
+			name: "should be invalid when minAvailable and maxUnavailable of the PodDisruptionBudget are set",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Replicas.PodDisruptionBudget = &PodDisruptionBudgetConfig{
+					MinAvailable:   ptr.To(intstr.FromInt32(1)),
+					MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+				}
+			},
+			wantErrorField: []string{"spec.companion.replicas.podDisruptionBudget.maxUnavailable"},
+		},
+		{
+			name: "should be valid when the exposure has a JWT access strategy",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Exposure = &ExposureConfig{
+					Type: ExposureTypeVirtualService,
+					Host: "companion.example.com",
+					AccessStrategy: AccessStrategy{
+						JWT: &JWTAccessStrategy{Issuer: "https://issuer", JWKSURL: "https://issuer/keys"},
+					},
+				}
+			},
+		},
+		{
+			name: "should be invalid when the exposure has no access strategy",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Exposure = &ExposureConfig{Host: "companion.example.com"}
+			},
+			wantErrorField: []string{"spec.companion.exposure.accessStrategy"},
+		},
+		{
+			name: "should be invalid when the exposure has a JWT and an OAuth2 access strategy",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Exposure = &ExposureConfig{
+					Host: "companion.example.com",
+					AccessStrategy: AccessStrategy{
+						JWT:    &JWTAccessStrategy{Issuer: "https://issuer", JWKSURL: "https://issuer/keys"},
+						OAuth2: &OAuth2AccessStrategy{},
+					},
+				}
+			},
+			wantErrorField: []string{"spec.companion.exposure.accessStrategy.oauth2"},
+		},
+		{
+			name: "should be invalid when the VirtualService exposure has an OAuth2 access strategy",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.Exposure = &ExposureConfig{
+					Type:           ExposureTypeVirtualService,
+					Host:           "companion.example.com",
+					AccessStrategy: AccessStrategy{OAuth2: &OAuth2AccessStrategy{}},
+				}
+			},
+			wantErrorField: []string{"spec.companion.exposure.accessStrategy.oauth2"},
+		},
+		{
+			name: "should be valid when the cluster access is limited to named resources",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.ClusterAccess = []ReadOnlyRule{
+					{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}},
+				}
+			},
+		},
+		{
+			name: "should be invalid when the cluster access uses wildcards",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.ClusterAccess = []ReadOnlyRule{
+					{APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+					{APIGroups: []string{"*"}, Resources: []string{"*", "*/scale"}},
+				}
+			},
+			wantErrorField: []string{
+				"spec.companion.clusterAccess[1].apiGroups[0]",
+				"spec.companion.clusterAccess[1].resources[0]",
+				"spec.companion.clusterAccess[1].resources[1]",
+			},
+		},
+		{
+			name: "should be invalid when the cluster access includes secrets and configmaps",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.Companion.ClusterAccess = []ReadOnlyRule{
+					{APIGroups: []string{""}, Resources: []string{"pods", "secrets", "configmaps/status"}},
+				}
+			},
+			wantErrorField: []string{
+				"spec.companion.clusterAccess[0].resources[1]",
+				"spec.companion.clusterAccess[0].resources[2]",
+			},
+		},
+		{
+			name: "should be invalid when a secret name is empty",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.HanaCloud.Secret.Name = ""
+			},
+			wantErrorField: []string{"spec.hanaCloud.secret.name"},
+		},
+		{
+			name: "should be invalid when secret names and namespaces are empty",
+			givenModifier: func(companion *Companion) {
+				companion.Spec.AICore.Secret = SecretSpec{}
+				companion.Spec.Redis.Secret.Namespace = ""
+				companion.Spec.Companion.Secret.Namespace = ""
+			},
+			wantErrorField: []string{
+				"spec.aicore.secret.name",
+				"spec.aicore.secret.namespace",
+				"spec.redis.secret.namespace",
+				"spec.companion.secret.namespace",
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			companion := newValidCompanion()
+			tc.givenModifier(companion)
+
+			// when
+			errs := companion.ValidateSpec()
+
+			// then
+			gotErrorFields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				gotErrorFields = append(gotErrorFields, err.Field)
+			}
+			require.ElementsMatch(t, tc.wantErrorField, gotErrorFields)
+		})
+	}
+}
+
+func newValidCompanion() *Companion {
+	return &Companion{
+		Spec: CompanionSpec{
+			AICore:    AICoreConfig{Secret: SecretSpec{Name: "ai-core", Namespace: "ai-core"}},
+			HanaCloud: HanaConfig{Secret: SecretSpec{Name: "companion", Namespace: "hana-cloud"}},
+			Redis:     RedisConfig{Secret: SecretSpec{Name: "companion", Namespace: "redis"}},
+			Companion: CompanionConfig{
+				Secret:   SecretSpec{Name: "companion", Namespace: "ai-core"},
+				Replicas: ReplicasConfig{Min: 1, Max: 3},
+			},
+		},
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	kctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Hub marks v1beta1 as the version to and from which all other versions of the Companion CR are converted.
+func (*Companion) Hub() {}
+
+// SetupWebhookWithManager registers the conversion webhook for the Companion CR in the manager.
+// The Companion CRs are validated by the validating webhook of v1alpha1, to which the API server converts them.
+func (r *Companion) SetupWebhookWithManager(mgr kctrl.Manager) error {
+	return kctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
@@ -23,19 +23,27 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	kcorev1 "k8s.io/api/core/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	kutilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
 	kkubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 	kctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	kctrllogzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	kctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	kctrlmetricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmv1beta1 "github.com/kyma-project/kyma-companion-manager/api/v1beta1"
 	"github.com/kyma-project/kyma-companion-manager/internal/backendmanager"
 	"github.com/kyma-project/kyma-companion-manager/internal/controller"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	kcmmetrics "github.com/kyma-project/kyma-companion-manager/internal/metrics"
 	"github.com/kyma-project/kyma-companion-manager/pkg/env"
 	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
 
@@ -54,6 +62,7 @@ func init() { //nolint:gochecknoinits // main function needs to initialize schem
 	kutilruntime.Must(kkubernetesscheme.AddToScheme(scheme))
 
 	kutilruntime.Must(kcmv1alpha1.AddToScheme(scheme))
+	kutilruntime.Must(kcmv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -128,6 +137,16 @@ func main() { //nolint:funlen // main function needs to initialize many object.
 			SecureServing: secureMetrics,
 			TLSOpts:       tlsOpts,
 		},
+		// only cache the pods of the companion backend, as they are only read to report their failures.
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&kcorev1.Pod{}: {
+					Label: klabels.SelectorFromSet(map[string]string{
+						kcmlabel.KeyManagedBy: kcmlabel.ValueControllerName,
+					}),
+				},
+			},
+		},
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -156,7 +175,7 @@ func main() { //nolint:funlen // main function needs to initialize many object.
 		setupLog.Error(err, "unable to start dynamicClient")
 		os.Exit(1)
 	}
-	kubeClient := kcmk8s.NewKubeClient(k8sClient, "kyma-companion-manager", dynamicClient)
+	kubeClient := kcmk8s.NewKubeClient(k8sClient, kcmk8s.FieldManager, dynamicClient)
 
 	backendManager := backendmanager.NewBackendManager(
 		k8sClient,
@@ -164,6 +183,13 @@ func main() { //nolint:funlen // main function needs to initialize many object.
 		sugaredLogger,
 	)
 
+	// setup custom metrics.
+	metricsCollector := kcmmetrics.NewCollector()
+	if err = metricsCollector.RegisterMetrics(kctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
 	// setup controller.
 	kcmController := controller.NewReconciler(
 		k8sClient,
@@ -171,6 +197,8 @@ func main() { //nolint:funlen // main function needs to initialize many object.
 		backendManager,
 		mgr.GetScheme(),
 		sugaredLogger,
+		mgr.GetEventRecorderFor(controller.ControllerName),
+		metricsCollector,
 		configs,
 	)
 
@@ -178,6 +206,18 @@ func main() { //nolint:funlen // main function needs to initialize many object.
 		setupLog.Error(err, "unable to create controller", "controller", "Companion")
 		os.Exit(1)
 	}
+
+	// setup webhooks.
+	if configs.EnableWebhooks {
+		if err = (&kcmv1alpha1.Companion{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Companion")
+			os.Exit(1)
+		}
+		if err = (&kcmv1beta1.Companion{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create conversion webhook", "webhook", "Companion")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
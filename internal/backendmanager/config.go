@@ -1,8 +1,17 @@
 package backendmanager
 
+import (
+	"time"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+)
+
 type Config struct {
 	HanaDB       []byte
 	Redis        []byte
 	AICoreSecret []byte
 	AICoreConfig []byte
+
+	// CredentialsLastModified contains the time of the last modification of each referenced secret.
+	CredentialsLastModified map[kcmv1alpha1.SecretSpec]time.Time
 }
@@ -0,0 +1,160 @@
+package backendmanager
+
+import (
+	"fmt"
+
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	kcmk8sapirule "github.com/kyma-project/kyma-companion-manager/pkg/k8s/apirule"
+	kcmk8sistio "github.com/kyma-project/kyma-companion-manager/pkg/k8s/istio"
+)
+
+const (
+	defaultExposureGateway = "kyma-system/kyma-gateway"
+	exposurePath           = "/.*"
+)
+
+// ExposureResources are the types of the resources through which the companion backend can be exposed.
+// The resources which are not needed for the configured exposure are deleted.
+//
+//nolint:gochecknoglobals // used as constant.
+var ExposureResources = []kschema.GroupVersionResource{
+	kcmk8sapirule.GroupVersionResource,
+	kcmk8sistio.VirtualServiceGVR,
+	kcmk8sistio.RequestAuthenticationGVR,
+	kcmk8sistio.AuthorizationPolicyGVR,
+}
+
+// exposureMethods are the HTTP methods which are allowed on the exposed companion backend.
+//
+//nolint:gochecknoglobals // used as constant.
+var exposureMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// GenerateNewExposure returns the resources which expose the companion backend outside of the cluster, keyed by
+// their resource type. It returns an empty map if the companion backend is not exposed.
+func (m *BackendManager) GenerateNewExposure(companion *kcmv1alpha1.Companion,
+) (map[kschema.GroupVersionResource]*kunstructured.Unstructured, error) {
+	exposure := companion.Spec.Companion.Exposure
+	if exposure == nil {
+		return map[kschema.GroupVersionResource]*kunstructured.Unstructured{}, nil
+	}
+
+	switch exposure.Type {
+	case kcmv1alpha1.ExposureTypeAPIRule, "":
+		apiRule, err := getAPIRule(companion, *exposure)
+		if err != nil {
+			return nil, err
+		}
+		return map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+			kcmk8sapirule.GroupVersionResource: apiRule,
+		}, nil
+	case kcmv1alpha1.ExposureTypeVirtualService:
+		return getVirtualServiceExposure(companion, *exposure)
+	default:
+		return nil, fmt.Errorf("unsupported exposure type: %s", exposure.Type)
+	}
+}
+
+// GetPublicURL returns the URL under which the companion backend is reachable from outside of the cluster.
+// It returns an empty string if the companion backend is not exposed.
+func GetPublicURL(companion *kcmv1alpha1.Companion) string {
+	if companion.Spec.Companion.Exposure == nil {
+		return ""
+	}
+	return "https://" + companion.Spec.Companion.Exposure.Host
+}
+
+func getAPIRule(companion *kcmv1alpha1.Companion, exposure kcmv1alpha1.ExposureConfig,
+) (*kunstructured.Unstructured, error) {
+	var accessStrategy kcmk8sapirule.AccessStrategy
+	switch strategy := exposure.AccessStrategy; {
+	case strategy.JWT != nil:
+		accessStrategy = kcmk8sapirule.AccessStrategy{
+			Handler: kcmk8sapirule.HandlerJWT,
+			Config: map[string]interface{}{
+				"trusted_issuers": []interface{}{strategy.JWT.Issuer},
+				"jwks_urls":       []interface{}{strategy.JWT.JWKSURL},
+			},
+		}
+	case strategy.OAuth2 != nil:
+		accessStrategy = kcmk8sapirule.AccessStrategy{Handler: kcmk8sapirule.HandlerOAuth2Introspection}
+		if len(strategy.OAuth2.RequiredScopes) > 0 {
+			scopes := make([]interface{}, 0, len(strategy.OAuth2.RequiredScopes))
+			for _, scope := range strategy.OAuth2.RequiredScopes {
+				scopes = append(scopes, scope)
+			}
+			accessStrategy.Config = map[string]interface{}{"required_scope": scopes}
+		}
+	default:
+		return nil, fmt.Errorf("no access strategy is set for the exposure of host %s", exposure.Host)
+	}
+
+	return kcmk8sapirule.NewAPIRule(
+		BackendResourceName,
+		companion.GetNamespace(),
+		kcmk8sapirule.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+		kcmk8sapirule.WithOwnerReferences(getOwnerReferences(*companion)),
+		kcmk8sapirule.WithHost(exposure.Host),
+		kcmk8sapirule.WithGateway(getExposureGateway(exposure)),
+		kcmk8sapirule.WithService(BackendResourceName, backendPortNum),
+		kcmk8sapirule.WithRule(exposurePath, exposureMethods, accessStrategy),
+	), nil
+}
+
+// getVirtualServiceExposure returns the VirtualService which routes the requests of the exposed host to the
+// companion backend, together with the RequestAuthentication and AuthorizationPolicy which deny the requests
+// without a valid JSON Web Token.
+func getVirtualServiceExposure(companion *kcmv1alpha1.Companion, exposure kcmv1alpha1.ExposureConfig,
+) (map[kschema.GroupVersionResource]*kunstructured.Unstructured, error) {
+	jwt := exposure.AccessStrategy.JWT
+	if jwt == nil {
+		return nil, fmt.Errorf("the %s exposure type requires the jwt access strategy",
+			kcmv1alpha1.ExposureTypeVirtualService)
+	}
+
+	namespace := companion.GetNamespace()
+	labels := kcmlabel.GetCommonLabels(BackendResourceName)
+	ownerReferences := getOwnerReferences(*companion)
+	serviceHost := fmt.Sprintf("%s.%s.svc.cluster.local", BackendResourceName, namespace)
+
+	return map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+		kcmk8sistio.VirtualServiceGVR: kcmk8sistio.NewVirtualService(BackendResourceName, namespace,
+			kcmk8sistio.WithLabels(labels),
+			kcmk8sistio.WithOwnerReferences(ownerReferences),
+			kcmk8sistio.WithHosts(exposure.Host),
+			kcmk8sistio.WithGateways(getExposureGateway(exposure)),
+			kcmk8sistio.WithHTTPRoute(serviceHost, backendPortNum),
+		),
+		kcmk8sistio.RequestAuthenticationGVR: kcmk8sistio.NewRequestAuthentication(BackendResourceName, namespace,
+			kcmk8sistio.WithLabels(labels),
+			kcmk8sistio.WithOwnerReferences(ownerReferences),
+			kcmk8sistio.WithWorkloadSelector(labels),
+			kcmk8sistio.WithJWTRule(jwt.Issuer, jwt.JWKSURL),
+		),
+		kcmk8sistio.AuthorizationPolicyGVR: kcmk8sistio.NewAuthorizationPolicy(BackendResourceName, namespace,
+			kcmk8sistio.WithLabels(labels),
+			kcmk8sistio.WithOwnerReferences(ownerReferences),
+			kcmk8sistio.WithWorkloadSelector(labels),
+			kcmk8sistio.WithDenyWithoutRequestPrincipal(exposure.Host),
+		),
+	}, nil
+}
+
+func getExposureGateway(exposure kcmv1alpha1.ExposureConfig) string {
+	if exposure.Gateway == "" {
+		return defaultExposureGateway
+	}
+	return exposure.Gateway
+}
+
+// getExposurePodLabels returns the labels which are required on the pods of the companion backend for the
+// exposure. The access strategies are enforced by the Istio sidecar, so it must be injected.
+func getExposurePodLabels(exposure *kcmv1alpha1.ExposureConfig) map[string]string {
+	if exposure == nil {
+		return nil
+	}
+	return map[string]string{kcmk8sistio.SidecarInjectionLabel: "true"}
+}
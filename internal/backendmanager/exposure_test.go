@@ -0,0 +1,194 @@
+package backendmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	kcmk8sapirule "github.com/kyma-project/kyma-companion-manager/pkg/k8s/apirule"
+	kcmk8sistio "github.com/kyma-project/kyma-companion-manager/pkg/k8s/istio"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+func Test_GenerateNewExposure(t *testing.T) {
+	t.Parallel()
+
+	givenJWT := &kcmv1alpha1.JWTAccessStrategy{
+		Issuer:  "https://issuer.example.com",
+		JWKSURL: "https://issuer.example.com/keys",
+	}
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenExposure *kcmv1alpha1.ExposureConfig
+		wantResources func(companion *kcmv1alpha1.Companion) map[kschema.GroupVersionResource]*kunstructured.Unstructured
+		wantError     bool
+	}{
+		{
+			name:          "should not expose the backend when no exposure is configured",
+			givenExposure: nil,
+			wantResources: func(_ *kcmv1alpha1.Companion) map[kschema.GroupVersionResource]*kunstructured.Unstructured {
+				return map[kschema.GroupVersionResource]*kunstructured.Unstructured{}
+			},
+		},
+		{
+			name: "should expose the backend through an APIRule with the JWT access strategy and the default gateway",
+			givenExposure: &kcmv1alpha1.ExposureConfig{
+				Host:           "companion.example.com",
+				AccessStrategy: kcmv1alpha1.AccessStrategy{JWT: givenJWT},
+			},
+			wantResources: func(companion *kcmv1alpha1.Companion) map[kschema.GroupVersionResource]*kunstructured.Unstructured {
+				return map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+					kcmk8sapirule.GroupVersionResource: kcmk8sapirule.NewAPIRule(BackendResourceName,
+						companion.Namespace,
+						kcmk8sapirule.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+						kcmk8sapirule.WithOwnerReferences(getOwnerReferences(*companion)),
+						kcmk8sapirule.WithHost("companion.example.com"),
+						kcmk8sapirule.WithGateway("kyma-system/kyma-gateway"),
+						kcmk8sapirule.WithService(BackendResourceName, backendPortNum),
+						kcmk8sapirule.WithRule("/.*", []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+							kcmk8sapirule.AccessStrategy{
+								Handler: "jwt",
+								Config: map[string]interface{}{
+									"trusted_issuers": []interface{}{"https://issuer.example.com"},
+									"jwks_urls":       []interface{}{"https://issuer.example.com/keys"},
+								},
+							}),
+					),
+				}
+			},
+		},
+		{
+			name: "should expose the backend through an APIRule with the OAuth2 access strategy",
+			givenExposure: &kcmv1alpha1.ExposureConfig{
+				Type:    kcmv1alpha1.ExposureTypeAPIRule,
+				Host:    "companion.example.com",
+				Gateway: "custom/gateway",
+				AccessStrategy: kcmv1alpha1.AccessStrategy{
+					OAuth2: &kcmv1alpha1.OAuth2AccessStrategy{RequiredScopes: []string{"read", "write"}},
+				},
+			},
+			wantResources: func(companion *kcmv1alpha1.Companion) map[kschema.GroupVersionResource]*kunstructured.Unstructured {
+				return map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+					kcmk8sapirule.GroupVersionResource: kcmk8sapirule.NewAPIRule(BackendResourceName,
+						companion.Namespace,
+						kcmk8sapirule.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+						kcmk8sapirule.WithOwnerReferences(getOwnerReferences(*companion)),
+						kcmk8sapirule.WithHost("companion.example.com"),
+						kcmk8sapirule.WithGateway("custom/gateway"),
+						kcmk8sapirule.WithService(BackendResourceName, backendPortNum),
+						kcmk8sapirule.WithRule("/.*", []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+							kcmk8sapirule.AccessStrategy{
+								Handler: "oauth2_introspection",
+								Config: map[string]interface{}{
+									"required_scope": []interface{}{"read", "write"},
+								},
+							}),
+					),
+				}
+			},
+		},
+		{
+			name: "should expose the backend through a VirtualService which requires a JWT",
+			givenExposure: &kcmv1alpha1.ExposureConfig{
+				Type:           kcmv1alpha1.ExposureTypeVirtualService,
+				Host:           "companion.example.com",
+				AccessStrategy: kcmv1alpha1.AccessStrategy{JWT: givenJWT},
+			},
+			wantResources: func(companion *kcmv1alpha1.Companion) map[kschema.GroupVersionResource]*kunstructured.Unstructured {
+				labels := kcmlabel.GetCommonLabels(BackendResourceName)
+				ownerReferences := getOwnerReferences(*companion)
+				return map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+					kcmk8sistio.VirtualServiceGVR: kcmk8sistio.NewVirtualService(BackendResourceName,
+						companion.Namespace,
+						kcmk8sistio.WithLabels(labels),
+						kcmk8sistio.WithOwnerReferences(ownerReferences),
+						kcmk8sistio.WithHosts("companion.example.com"),
+						kcmk8sistio.WithGateways("kyma-system/kyma-gateway"),
+						kcmk8sistio.WithHTTPRoute(BackendResourceName+"."+companion.Namespace+".svc.cluster.local",
+							backendPortNum),
+					),
+					kcmk8sistio.RequestAuthenticationGVR: kcmk8sistio.NewRequestAuthentication(BackendResourceName,
+						companion.Namespace,
+						kcmk8sistio.WithLabels(labels),
+						kcmk8sistio.WithOwnerReferences(ownerReferences),
+						kcmk8sistio.WithWorkloadSelector(labels),
+						kcmk8sistio.WithJWTRule("https://issuer.example.com", "https://issuer.example.com/keys"),
+					),
+					kcmk8sistio.AuthorizationPolicyGVR: kcmk8sistio.NewAuthorizationPolicy(BackendResourceName,
+						companion.Namespace,
+						kcmk8sistio.WithLabels(labels),
+						kcmk8sistio.WithOwnerReferences(ownerReferences),
+						kcmk8sistio.WithWorkloadSelector(labels),
+						kcmk8sistio.WithDenyWithoutRequestPrincipal("companion.example.com"),
+					),
+				}
+			},
+		},
+		{
+			name: "should return error when the VirtualService is used with the OAuth2 access strategy",
+			givenExposure: &kcmv1alpha1.ExposureConfig{
+				Type: kcmv1alpha1.ExposureTypeVirtualService,
+				Host: "companion.example.com",
+				AccessStrategy: kcmv1alpha1.AccessStrategy{
+					OAuth2: &kcmv1alpha1.OAuth2AccessStrategy{},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "should return error when no access strategy is set",
+			givenExposure: &kcmv1alpha1.ExposureConfig{
+				Host: "companion.example.com",
+			},
+			wantError: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.Exposure = tc.givenExposure
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+			backendManager := NewBackendManager(nil, nil, logger)
+
+			// when
+			gotResources, err := backendManager.GenerateNewExposure(givenCompanion)
+
+			// then
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantResources(givenCompanion), gotResources)
+		})
+	}
+}
+
+func Test_GetPublicURL(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+
+	// when, then
+	require.Empty(t, GetPublicURL(givenCompanion))
+
+	// given
+	givenCompanion.Spec.Companion.Exposure = &kcmv1alpha1.ExposureConfig{Host: "companion.example.com"}
+
+	// when, then
+	require.Equal(t, "https://companion.example.com", GetPublicURL(givenCompanion))
+}
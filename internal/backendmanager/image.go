@@ -0,0 +1,57 @@
+package backendmanager
+
+import (
+	"strings"
+
+	kcorev1 "k8s.io/api/core/v1"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+)
+
+// defaultImagePullPolicy is the pull policy of the backend image, if the Companion CR does not define one.
+const defaultImagePullPolicy = kcorev1.PullAlways
+
+// ResolveImage returns the image of the companion backend. The fields set in the given image config
+// take precedence over the ones of the given default image.
+func ResolveImage(defaultImage string, image *kcmv1alpha1.ImageConfig) string {
+	if image == nil {
+		return defaultImage
+	}
+
+	repository, tag, digest := splitImage(defaultImage)
+	if image.Repository != "" {
+		repository = image.Repository
+	}
+	switch {
+	case image.Digest != "":
+		return repository + "@" + image.Digest
+	case image.Tag != "":
+		return repository + ":" + image.Tag
+	case digest != "":
+		return repository + "@" + digest
+	case tag != "":
+		return repository + ":" + tag
+	default:
+		return repository
+	}
+}
+
+// getImagePullPolicy returns the pull policy of the backend image from the given image config.
+func getImagePullPolicy(image *kcmv1alpha1.ImageConfig) kcorev1.PullPolicy {
+	if image == nil || image.PullPolicy == "" {
+		return defaultImagePullPolicy
+	}
+	return image.PullPolicy
+}
+
+// splitImage splits the given image reference into its repository, tag and digest.
+func splitImage(image string) (string, string, string) {
+	repository, digest, _ := strings.Cut(image, "@")
+
+	// a colon after the last slash separates the tag, other colons belong to the registry port.
+	tag := ""
+	if index := strings.LastIndex(repository, ":"); index > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:index], repository[index+1:]
+	}
+	return repository, tag, digest
+}
@@ -0,0 +1,113 @@
+package backendmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+)
+
+func Test_ResolveImage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		givenDefaultImage = "europe-docker.pkg.dev/kyma-project/prod/kyma-companion:1.0.0"
+		givenDigest       = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	// define test cases
+	testCases := []struct {
+		name         string
+		givenDefault string
+		givenImage   *kcmv1alpha1.ImageConfig
+		wantImage    string
+	}{
+		{
+			name:         "should use the default image when no image is configured",
+			givenDefault: givenDefaultImage,
+			givenImage:   nil,
+			wantImage:    givenDefaultImage,
+		},
+		{
+			name:         "should override the tag of the default image",
+			givenDefault: givenDefaultImage,
+			givenImage:   &kcmv1alpha1.ImageConfig{Tag: "2.0.0-rc1"},
+			wantImage:    "europe-docker.pkg.dev/kyma-project/prod/kyma-companion:2.0.0-rc1",
+		},
+		{
+			name:         "should override the repository and keep the tag of the default image",
+			givenDefault: givenDefaultImage,
+			givenImage:   &kcmv1alpha1.ImageConfig{Repository: "localhost:5000/kyma-companion"},
+			wantImage:    "localhost:5000/kyma-companion:1.0.0",
+		},
+		{
+			name:         "should prefer the digest over the tag",
+			givenDefault: givenDefaultImage,
+			givenImage:   &kcmv1alpha1.ImageConfig{Tag: "2.0.0", Digest: givenDigest},
+			wantImage:    "europe-docker.pkg.dev/kyma-project/prod/kyma-companion@" + givenDigest,
+		},
+		{
+			name:         "should replace the digest of the default image with the given tag",
+			givenDefault: "kyma-companion@" + givenDigest,
+			givenImage:   &kcmv1alpha1.ImageConfig{Tag: "2.0.0"},
+			wantImage:    "kyma-companion:2.0.0",
+		},
+		{
+			name:         "should not mistake the registry port for a tag",
+			givenDefault: "localhost:5000/kyma-companion",
+			givenImage:   &kcmv1alpha1.ImageConfig{PullPolicy: kcorev1.PullNever},
+			wantImage:    "localhost:5000/kyma-companion",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, tc.wantImage, ResolveImage(tc.givenDefault, tc.givenImage))
+		})
+	}
+}
+
+func Test_getImagePullPolicy(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name       string
+		givenImage *kcmv1alpha1.ImageConfig
+		wantPolicy kcorev1.PullPolicy
+	}{
+		{
+			name:       "should default to Always when no image is configured",
+			givenImage: nil,
+			wantPolicy: kcorev1.PullAlways,
+		},
+		{
+			name:       "should default to Always when no pull policy is configured",
+			givenImage: &kcmv1alpha1.ImageConfig{Tag: "2.0.0"},
+			wantPolicy: kcorev1.PullAlways,
+		},
+		{
+			name:       "should use the configured pull policy",
+			givenImage: &kcmv1alpha1.ImageConfig{PullPolicy: kcorev1.PullIfNotPresent},
+			wantPolicy: kcorev1.PullIfNotPresent,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, tc.wantPolicy, getImagePullPolicy(tc.givenImage))
+		})
+	}
+}
@@ -3,18 +3,34 @@ package backendmanager
 import (
 	"context"
 	"encoding/json"
-	"strings"
+	"errors"
+	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
 	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
 	kcmk8sdeployment "github.com/kyma-project/kyma-companion-manager/pkg/k8s/deployment"
+	kcmk8shpa "github.com/kyma-project/kyma-companion-manager/pkg/k8s/hpa"
+	kcmk8snetworkpolicy "github.com/kyma-project/kyma-companion-manager/pkg/k8s/networkpolicy"
+	kcmk8spdb "github.com/kyma-project/kyma-companion-manager/pkg/k8s/pdb"
+	kcmk8srbac "github.com/kyma-project/kyma-companion-manager/pkg/k8s/rbac"
 	kcmk8ssecret "github.com/kyma-project/kyma-companion-manager/pkg/k8s/secret"
+	kcmk8sservice "github.com/kyma-project/kyma-companion-manager/pkg/k8s/service"
+	kcmk8sserviceaccount "github.com/kyma-project/kyma-companion-manager/pkg/k8s/serviceaccount"
 )
 
 const (
@@ -31,18 +47,47 @@ const (
 	requestsMemory                = "512Mi"
 	limitsCPU                     = "500m"
 	limitsMemory                  = "1Gi"
-	deploymentReplicas            = 1
 	secretMountPath               = "/mnt/secrets"
+	tmpVolumeName                 = "tmp"
+	tmpMountPath                  = "/tmp"
+	zoneSpreadMaxSkew             = int32(1)
+	defaultMinReplicas            = int32(1)
+	defaultMaxReplicas            = int32(3)
+	defaultTargetCPUUtilization   = int32(80)
+	defaultPDBMaxUnavailable      = int32(1)
+	backendSecretKeyHanaDB        = "hana-db-secret"
+	backendSecretKeyRedis         = "redis-secret"
+	backendSecretKeyAICoreConfig  = "ai-core-config"
+	backendSecretKeyAICoreSecret  = "ai-core-secret"
 )
 
+// AnnotationKeySecretChecksum is the pod annotation of the companion backend which holds the checksum of the
+// data of the backend secret.
+const AnnotationKeySecretChecksum = "companion.operator.kyma-project.io/secret-checksum"
+
+// ErrDependencyNotFound is returned when a secret or configMap referenced in the Companion CR does not exist.
+var ErrDependencyNotFound = errors.New("backend dependency not found")
+
 // compile-time check.
 var _ Manager = &BackendManager{}
 
 //go:generate go run github.com/vektra/mockery/v2 --name=Manager --outpkg=mocks --case=underscore
 type Manager interface {
-	GenerateNewDeployment(companion *kcmv1alpha1.Companion, backendImage string) (*kappsv1.Deployment, error)
+	GenerateNewDeployment(companion *kcmv1alpha1.Companion, backendImage string,
+		backendSecret *kcorev1.Secret) (*kappsv1.Deployment, error)
 	GenerateNewSecret(companion *kcmv1alpha1.Companion, config Config) (*kcorev1.Secret, error)
-	GetBackendConfig(ctx context.Context) (*Config, error)
+	GenerateNewHPA(companion *kcmv1alpha1.Companion) (*kautoscalingv2.HorizontalPodAutoscaler, error)
+	GenerateNewService(companion *kcmv1alpha1.Companion) (*kcorev1.Service, error)
+	GenerateNewPDB(companion *kcmv1alpha1.Companion) (*kpolicyv1.PodDisruptionBudget, error)
+	GenerateNewNetworkPolicy(companion *kcmv1alpha1.Companion,
+		backendSecret *kcorev1.Secret) (*knetworkingv1.NetworkPolicy, error)
+	GenerateNewServiceAccount(companion *kcmv1alpha1.Companion) (*kcorev1.ServiceAccount, error)
+	GenerateNewClusterRole(companion *kcmv1alpha1.Companion) (*krbacv1.ClusterRole, error)
+	GenerateNewClusterRoleBinding(companion *kcmv1alpha1.Companion) (*krbacv1.ClusterRoleBinding, error)
+	GenerateNewExposure(companion *kcmv1alpha1.Companion) (
+		map[kschema.GroupVersionResource]*kunstructured.Unstructured, error)
+	GetBackendConfig(ctx context.Context, companion *kcmv1alpha1.Companion) (*Config, error)
+	GetManagedResources(companion *kcmv1alpha1.Companion) []client.Object
 }
 
 type BackendManager struct {
@@ -63,8 +108,10 @@ func NewBackendManager(
 	}
 }
 
+// GenerateNewDeployment returns the deployment of the companion backend. The checksum of the given backend
+// secret is set as pod annotation, so that the pods are restarted when the data of the secret changes.
 func (m *BackendManager) GenerateNewDeployment(companion *kcmv1alpha1.Companion,
-	backendImage string,
+	backendImage string, backendSecret *kcorev1.Secret,
 ) (*kappsv1.Deployment, error) {
 	// define labels.
 	labels := kcmlabel.GetCommonLabels(BackendResourceName)
@@ -77,15 +124,19 @@ func (m *BackendManager) GenerateNewDeployment(companion *kcmv1alpha1.Companion,
 			Ports:           getContainerPorts(),
 			LivenessProbe:   getLivenessProbe(),
 			ReadinessProbe:  getReadinessProbe(),
-			ImagePullPolicy: kcorev1.PullAlways,
-			// SecurityContext: getContainerSecurityContext(),
-			Resources: getResources(requestsCPU, requestsMemory, limitsCPU, limitsMemory),
+			ImagePullPolicy: getImagePullPolicy(companion.Spec.Companion.Image),
+			SecurityContext: getContainerSecurityContext(companion.Spec.Companion.WritableRootFilesystem),
+			Resources:       getResources(companion.Spec.Companion.Resources),
 			VolumeMounts: []kcorev1.VolumeMount{
 				{
 					Name:      BackendResourceName,
 					ReadOnly:  true,
 					MountPath: secretMountPath,
 				},
+				{
+					Name:      tmpVolumeName,
+					MountPath: tmpMountPath,
+				},
 			},
 		},
 	}
@@ -95,15 +146,26 @@ func (m *BackendManager) GenerateNewDeployment(companion *kcmv1alpha1.Companion,
 		BackendResourceName,
 		companion.GetNamespace(),
 		kcmk8sdeployment.WithLabels(labels),
+		kcmk8sdeployment.WithPodLabels(getExposurePodLabels(companion.Spec.Companion.Exposure)),
+		kcmk8sdeployment.WithPodAnnotations(map[string]string{
+			AnnotationKeySecretChecksum: getSecretChecksum(backendSecret),
+		}),
 		kcmk8sdeployment.WithRestartPolicyAlways(),
-		kcmk8sdeployment.WithReplicas(deploymentReplicas),
-		// kcmk8sdeployment.WithSecurityContext(getPodSecurityContext()),
+		kcmk8sdeployment.WithSecurityContext(getPodSecurityContext()),
+		kcmk8sdeployment.WithServiceAccountName(BackendResourceName),
 		kcmk8sdeployment.WithTerminationGracePeriodSeconds(terminationGracePeriodSeconds),
 		kcmk8sdeployment.WithPriorityClassName(priorityClassName),
 		kcmk8sdeployment.WithSelectorLabels(labels),
 		kcmk8sdeployment.WithContainers(containers),
 		kcmk8sdeployment.WithOwnerReferences(getOwnerReferences(*companion)),
 		kcmk8sdeployment.WithVolumeMountedSecret(BackendResourceName),
+		kcmk8sdeployment.WithEmptyDirVolume(tmpVolumeName),
+		kcmk8sdeployment.WithTolerations(companion.Spec.Companion.Tolerations),
+		kcmk8sdeployment.WithAffinity(companion.Spec.Companion.Affinity),
+		kcmk8sdeployment.WithTopologySpreadConstraints(
+			getTopologySpreadConstraints(companion.Spec.Companion.TopologySpreadConstraints, labels),
+		),
+		kcmk8sdeployment.WithNodeSelector(companion.Spec.Companion.NodeSelector),
 	)
 
 	return deployment, nil
@@ -116,72 +178,192 @@ func (m *BackendManager) GenerateNewSecret(companion *kcmv1alpha1.Companion, con
 		companion.GetNamespace(),
 		kcmk8ssecret.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
 		kcmk8ssecret.WithOwnerReferences(getOwnerReferences(*companion)),
-		kcmk8ssecret.WithDataKeyKey("hana-db-secret", config.HanaDB),
-		kcmk8ssecret.WithDataKeyKey("redis-secret", config.Redis),
-		kcmk8ssecret.WithDataKeyKey("ai-core-config", config.AICoreConfig),
-		kcmk8ssecret.WithDataKeyKey("ai-core-secret", config.AICoreSecret),
+		kcmk8ssecret.WithDataKeyKey(backendSecretKeyHanaDB, config.HanaDB),
+		kcmk8ssecret.WithDataKeyKey(backendSecretKeyRedis, config.Redis),
+		kcmk8ssecret.WithDataKeyKey(backendSecretKeyAICoreConfig, config.AICoreConfig),
+		kcmk8ssecret.WithDataKeyKey(backendSecretKeyAICoreSecret, config.AICoreSecret),
 	)
 
 	return secret, nil
 }
 
-func (m *BackendManager) GetBackendConfig(ctx context.Context) (*Config, error) {
-	// define config object.
-	config := &Config{}
+// GenerateNewHPA returns the HorizontalPodAutoscaler which scales the deployment of the companion backend
+// based on the replicas config of the Companion CR. The replicas of the deployment itself are left unset.
+func (m *BackendManager) GenerateNewHPA(companion *kcmv1alpha1.Companion,
+) (*kautoscalingv2.HorizontalPodAutoscaler, error) {
+	replicas := companion.Spec.Companion.Replicas
+	minReplicas, maxReplicas := getMinMaxReplicas(replicas)
+
+	opts := []kcmk8shpa.Opt{
+		kcmk8shpa.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+		kcmk8shpa.WithOwnerReferences(getOwnerReferences(*companion)),
+		kcmk8shpa.WithScaleTargetDeployment(BackendResourceName),
+		kcmk8shpa.WithMinReplicas(minReplicas),
+		kcmk8shpa.WithMaxReplicas(maxReplicas),
+	}
+
+	// use the CPU utilization as default metric, if no target is defined.
+	if replicas.TargetCPUUtilizationPercentage == nil && replicas.TargetMemoryUtilizationPercentage == nil {
+		opts = append(opts, kcmk8shpa.WithResourceUtilizationMetric(kcorev1.ResourceCPU, defaultTargetCPUUtilization))
+	}
+	if replicas.TargetCPUUtilizationPercentage != nil {
+		opts = append(opts, kcmk8shpa.WithResourceUtilizationMetric(kcorev1.ResourceCPU,
+			*replicas.TargetCPUUtilizationPercentage))
+	}
+	if replicas.TargetMemoryUtilizationPercentage != nil {
+		opts = append(opts, kcmk8shpa.WithResourceUtilizationMetric(kcorev1.ResourceMemory,
+			*replicas.TargetMemoryUtilizationPercentage))
+	}
 
-	// hard-coded secret namespaced names for first iteration.
-	hanaDBSecretNamespacedName := "kyma-system/companion-hana-db"
-	redisSecretNamespacedName := "kyma-system/companion-redis"
-	aiCoreSecretNamespacedName := "kyma-system/companion-ai-core"
-	aiCoreConfigNamespacedName := "kyma-system/companion-ai-core"
+	return kcmk8shpa.NewHPA(BackendResourceName, companion.GetNamespace(), opts...), nil
+}
+
+// GenerateNewService returns the Service which exposes the http and metrics ports of the companion backend
+// inside the cluster.
+func (m *BackendManager) GenerateNewService(companion *kcmv1alpha1.Companion) (*kcorev1.Service, error) {
+	labels := kcmlabel.GetCommonLabels(BackendResourceName)
+	service := kcmk8sservice.NewService(
+		BackendResourceName,
+		companion.GetNamespace(),
+		kcmk8sservice.WithLabels(labels),
+		kcmk8sservice.WithOwnerReferences(getOwnerReferences(*companion)),
+		kcmk8sservice.WithSelector(labels),
+		kcmk8sservice.WithPort(backendPortName, backendPortNum, backendPortName),
+		kcmk8sservice.WithPort(backendMetricsPortName, backendMetricsPortNum, backendMetricsPortName),
+	)
+
+	return service, nil
+}
+
+// GenerateNewPDB returns the PodDisruptionBudget which protects the companion backend from losing all replicas
+// at once during voluntary disruptions. It returns nil if the max replicas are not greater than 1, because a
+// PodDisruptionBudget would block node drains in that case.
+func (m *BackendManager) GenerateNewPDB(companion *kcmv1alpha1.Companion,
+) (*kpolicyv1.PodDisruptionBudget, error) {
+	replicas := companion.Spec.Companion.Replicas
+	if _, maxReplicas := getMinMaxReplicas(replicas); maxReplicas <= 1 {
+		return nil, nil //nolint:nilnil // no PodDisruptionBudget is needed.
+	}
+
+	labels := kcmlabel.GetCommonLabels(BackendResourceName)
+	opts := []kcmk8spdb.Opt{
+		kcmk8spdb.WithLabels(labels),
+		kcmk8spdb.WithOwnerReferences(getOwnerReferences(*companion)),
+		kcmk8spdb.WithSelector(labels),
+		kcmk8spdb.WithMaxUnavailable(intstr.FromInt32(defaultPDBMaxUnavailable)),
+	}
+
+	if pdbConfig := replicas.PodDisruptionBudget; pdbConfig != nil {
+		if pdbConfig.MinAvailable != nil {
+			opts = append(opts, kcmk8spdb.WithMinAvailable(*pdbConfig.MinAvailable))
+		}
+		if pdbConfig.MaxUnavailable != nil {
+			opts = append(opts, kcmk8spdb.WithMaxUnavailable(*pdbConfig.MaxUnavailable))
+		}
+	}
+
+	return kcmk8spdb.NewPDB(BackendResourceName, companion.GetNamespace(), opts...), nil
+}
+
+// GetManagedResources returns all resources which are managed for the given Companion CR.
+// The returned objects only define the kind, name and namespace of the resources. The cluster-scoped
+// resources are included, as they are not deleted by the garbage collection of the Companion CR. The exposure
+// resources are not included, as their CustomResourceDefinitions are optional, see ExposureResources.
+func (m *BackendManager) GetManagedResources(companion *kcmv1alpha1.Companion) []client.Object {
+	namespace := companion.GetNamespace()
+	return []client.Object{
+		kcmk8snetworkpolicy.NewNetworkPolicy(BackendResourceName, namespace),
+		kcmk8spdb.NewPDB(BackendResourceName, namespace),
+		kcmk8shpa.NewHPA(BackendResourceName, namespace),
+		kcmk8sservice.NewService(BackendResourceName, namespace),
+		kcmk8sdeployment.NewDeployment(BackendResourceName, namespace),
+		kcmk8ssecret.NewSecret(BackendResourceName, namespace),
+		kcmk8srbac.NewClusterRoleBinding(BackendResourceName),
+		kcmk8srbac.NewClusterRole(BackendResourceName),
+		kcmk8sserviceaccount.NewServiceAccount(BackendResourceName, namespace),
+	}
+}
+
+func (m *BackendManager) GetBackendConfig(ctx context.Context, companion *kcmv1alpha1.Companion) (*Config, error) {
+	config := &Config{CredentialsLastModified: map[kcmv1alpha1.SecretSpec]time.Time{}}
 
 	// Fetch the secret for HANA Vector DB.
-	parts := strings.Split(hanaDBSecretNamespacedName, "/")
-	hanaDBSecret, err := m.kubeClient.GetSecret(ctx, parts[1], parts[0])
+	hanaDB, err := m.getSecretData(ctx, companion.Spec.HanaCloud.Secret, config)
 	if err != nil {
 		return nil, err
 	}
-	jsonString, err := json.Marshal(hanaDBSecret.Data)
+
+	// Fetch the secret for Redis.
+	redis, err := m.getSecretData(ctx, companion.Spec.Redis.Secret, config)
 	if err != nil {
 		return nil, err
 	}
-	config.HanaDB = jsonString
 
-	// Fetch the secret for Redis.
-	parts = strings.Split(redisSecretNamespacedName, "/")
-	redisSecret, err := m.kubeClient.GetSecret(ctx, parts[1], parts[0])
+	// Fetch the secret for AI-Core.
+	aiCoreSecret, err := m.getSecretData(ctx, companion.Spec.AICore.Secret, config)
 	if err != nil {
 		return nil, err
 	}
-	jsonString, err = json.Marshal(redisSecret.Data)
+
+	// Fetch the configMap for AI-Core. It is expected to have the same name and namespace as the AI-Core secret.
+	aiCoreConfig, err := m.getConfigMapData(ctx, companion.Spec.AICore.Secret)
 	if err != nil {
 		return nil, err
 	}
-	config.Redis = jsonString
 
-	// Fetch the secret for AI-Core.
-	parts = strings.Split(aiCoreSecretNamespacedName, "/")
-	aiCoreSecret, err := m.kubeClient.GetSecret(ctx, parts[1], parts[0])
-	if err != nil {
+	// Validate the structure of the dependencies, so that a misconfiguration is reported on the Companion CR
+	// instead of failing inside the companion backend.
+	if err = validateDependencies(
+		newDependency(hanaDBSchema, companion.Spec.HanaCloud.Secret, secretDataToStrings(hanaDB)),
+		newDependency(redisSchema, companion.Spec.Redis.Secret, secretDataToStrings(redis)),
+		newDependency(aiCoreSecretSchema, companion.Spec.AICore.Secret, secretDataToStrings(aiCoreSecret)),
+		newDependency(aiCoreConfigSchema, companion.Spec.AICore.Secret, aiCoreConfig),
+	); err != nil {
 		return nil, err
 	}
-	jsonString, err = json.Marshal(aiCoreSecret.Data)
-	if err != nil {
+
+	if config.HanaDB, err = json.Marshal(hanaDB); err != nil {
+		return nil, err
+	}
+	if config.Redis, err = json.Marshal(redis); err != nil {
+		return nil, err
+	}
+	if config.AICoreSecret, err = json.Marshal(aiCoreSecret); err != nil {
+		return nil, err
+	}
+	if config.AICoreConfig, err = json.Marshal(aiCoreConfig); err != nil {
 		return nil, err
 	}
-	config.AICoreSecret = jsonString
 
-	// Fetch the configMap for AI-Core.
-	parts = strings.Split(aiCoreConfigNamespacedName, "/")
-	aiCoreConfigMap, err := m.kubeClient.GetConfigMap(ctx, parts[1], parts[0])
+	return config, nil
+}
+
+// getSecretData fetches the secret referenced by the given SecretSpec and returns its data.
+// The time of the last modification of the secret is recorded in the given config.
+func (m *BackendManager) getSecretData(ctx context.Context, secretSpec kcmv1alpha1.SecretSpec,
+	config *Config,
+) (map[string][]byte, error) {
+	secret, err := m.kubeClient.GetSecret(ctx, secretSpec.Name, secretSpec.Namespace)
 	if err != nil {
+		if kapierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: secret %s/%s", ErrDependencyNotFound, secretSpec.Namespace, secretSpec.Name)
+		}
 		return nil, err
 	}
-	jsonString, err = json.Marshal(aiCoreConfigMap.Data)
+	config.CredentialsLastModified[secretSpec] = getLastModifiedTime(secret)
+	return secret.Data, nil
+}
+
+// getConfigMapData fetches the configMap referenced by the given SecretSpec and returns its data.
+func (m *BackendManager) getConfigMapData(ctx context.Context,
+	secretSpec kcmv1alpha1.SecretSpec,
+) (map[string]string, error) {
+	configMap, err := m.kubeClient.GetConfigMap(ctx, secretSpec.Name, secretSpec.Namespace)
 	if err != nil {
+		if kapierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: configmap %s/%s", ErrDependencyNotFound, secretSpec.Namespace, secretSpec.Name)
+		}
 		return nil, err
 	}
-	config.AICoreConfig = jsonString
-
-	return config, nil
+	return configMap.Data, nil
 }
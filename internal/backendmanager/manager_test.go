@@ -2,14 +2,23 @@ package backendmanager
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
 	kcmk8smocks "github.com/kyma-project/kyma-companion-manager/pkg/k8s/mocks"
 	"github.com/kyma-project/kyma-companion-manager/pkg/utils"
@@ -26,9 +35,12 @@ func Test_GenerateNewDeployment(t *testing.T) {
 	givenTerminationGracePeriodSeconds := terminationGracePeriodSeconds
 	require.NoError(t, err)
 	backendManager := NewBackendManager(nil, nil, logger)
+	givenSecret := &kcorev1.Secret{
+		Data: map[string][]byte{"redis-secret": []byte("redis")},
+	}
 
 	// when
-	gotDeployment, err := backendManager.GenerateNewDeployment(givenCompanion, givenBackendImage)
+	gotDeployment, err := backendManager.GenerateNewDeployment(givenCompanion, givenBackendImage, givenSecret)
 
 	// then
 	require.NoError(t, err)
@@ -44,16 +56,19 @@ func Test_GenerateNewDeployment(t *testing.T) {
 			OwnerReferences: getOwnerReferences(*givenCompanion),
 		},
 		Spec: kappsv1.DeploymentSpec{
-			Replicas: utils.Int32Ptr(deploymentReplicas),
 			Selector: kmetav1.SetAsLabelSelector(kcmlabel.GetCommonLabels(BackendResourceName)),
 			Template: kcorev1.PodTemplateSpec{
 				ObjectMeta: kmetav1.ObjectMeta{
 					Name:   BackendResourceName,
 					Labels: kcmlabel.GetCommonLabels(BackendResourceName),
+					Annotations: map[string]string{
+						AnnotationKeySecretChecksum: getSecretChecksum(givenSecret),
+					},
 				},
 				Spec: kcorev1.PodSpec{
-					RestartPolicy: kcorev1.RestartPolicyAlways,
-					// SecurityContext:               getPodSecurityContext(),
+					RestartPolicy:                 kcorev1.RestartPolicyAlways,
+					SecurityContext:               getPodSecurityContext(),
+					ServiceAccountName:            BackendResourceName,
 					TerminationGracePeriodSeconds: &givenTerminationGracePeriodSeconds,
 					PriorityClassName:             priorityClassName,
 					Containers: []kcorev1.Container{
@@ -64,14 +79,18 @@ func Test_GenerateNewDeployment(t *testing.T) {
 							LivenessProbe:   getLivenessProbe(),
 							ReadinessProbe:  getReadinessProbe(),
 							ImagePullPolicy: kcorev1.PullAlways,
-							// SecurityContext: getContainerSecurityContext(),
-							Resources: getResources(requestsCPU, requestsMemory, limitsCPU, limitsMemory),
+							SecurityContext: getContainerSecurityContext(false),
+							Resources:       getDefaultResources(),
 							VolumeMounts: []kcorev1.VolumeMount{
 								{
 									Name:      BackendResourceName,
 									ReadOnly:  true,
 									MountPath: secretMountPath,
 								},
+								{
+									Name:      tmpVolumeName,
+									MountPath: tmpMountPath,
+								},
 							},
 						},
 					},
@@ -85,6 +104,20 @@ func Test_GenerateNewDeployment(t *testing.T) {
 								},
 							},
 						},
+						{
+							Name: tmpVolumeName,
+							VolumeSource: kcorev1.VolumeSource{
+								EmptyDir: &kcorev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					TopologySpreadConstraints: []kcorev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: kcorev1.ScheduleAnyway,
+							LabelSelector:     kmetav1.SetAsLabelSelector(kcmlabel.GetCommonLabels(BackendResourceName)),
+						},
 					},
 				},
 			},
@@ -104,6 +137,72 @@ func Test_GenerateNewDeployment(t *testing.T) {
 	require.Equal(t, wantDeployment, gotDeployment)
 }
 
+func Test_GenerateNewDeployment_Scheduling(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenTolerations := []kcorev1.Toleration{
+		{Key: "dedicated", Operator: kcorev1.TolerationOpEqual, Value: "companion", Effect: kcorev1.TaintEffectNoSchedule},
+	}
+	givenAffinity := &kcorev1.Affinity{
+		NodeAffinity: &kcorev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &kcorev1.NodeSelector{
+				NodeSelectorTerms: []kcorev1.NodeSelectorTerm{{
+					MatchExpressions: []kcorev1.NodeSelectorRequirement{
+						{Key: "kubernetes.io/arch", Operator: kcorev1.NodeSelectorOpIn, Values: []string{"amd64"}},
+					},
+				}},
+			},
+		},
+	}
+	givenConstraints := []kcorev1.TopologySpreadConstraint{
+		{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: kcorev1.DoNotSchedule},
+	}
+	givenNodeSelector := map[string]string{"pool": "companion"}
+
+	givenCompanion := testutils.NewCompanionCR()
+	givenCompanion.Spec.Companion.Tolerations = givenTolerations
+	givenCompanion.Spec.Companion.Affinity = givenAffinity
+	givenCompanion.Spec.Companion.TopologySpreadConstraints = givenConstraints
+	givenCompanion.Spec.Companion.NodeSelector = givenNodeSelector
+
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotDeployment, err := backendManager.GenerateNewDeployment(givenCompanion, "image", &kcorev1.Secret{})
+
+	// then
+	require.NoError(t, err)
+	gotPodSpec := gotDeployment.Spec.Template.Spec
+	require.Equal(t, givenTolerations, gotPodSpec.Tolerations)
+	require.Equal(t, givenAffinity, gotPodSpec.Affinity)
+	require.Equal(t, givenConstraints, gotPodSpec.TopologySpreadConstraints)
+	require.Equal(t, givenNodeSelector, gotPodSpec.NodeSelector)
+}
+
+func Test_GenerateNewDeployment_Exposure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	givenCompanion.Spec.Companion.Exposure = &kcmv1alpha1.ExposureConfig{Host: "companion.example.com"}
+
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotDeployment, err := backendManager.GenerateNewDeployment(givenCompanion, "image", &kcorev1.Secret{})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, "true", gotDeployment.Spec.Template.Labels["sidecar.istio.io/inject"])
+	require.Equal(t, kcmlabel.GetCommonLabels(BackendResourceName), gotDeployment.Labels)
+	require.Equal(t, kcmlabel.GetCommonLabels(BackendResourceName), gotDeployment.Spec.Selector.MatchLabels)
+}
+
 func Test_GenerateNewSecret(t *testing.T) {
 	t.Parallel()
 
@@ -158,48 +257,424 @@ func Test_GenerateNewSecret(t *testing.T) {
 	require.Equal(t, wantSecret, gotSecret)
 }
 
-func Test_GetBackendConfig(t *testing.T) {
+func Test_GenerateNewService(t *testing.T) {
 	t.Parallel()
 
 	// given
+	givenCompanion := testutils.NewCompanionCR()
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotService, err := backendManager.GenerateNewService(givenCompanion)
+
+	// then
+	require.NoError(t, err)
+	wantService := &kcorev1.Service{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:            BackendResourceName,
+			Namespace:       givenCompanion.Namespace,
+			Labels:          kcmlabel.GetCommonLabels(BackendResourceName),
+			OwnerReferences: getOwnerReferences(*givenCompanion),
+		},
+		Spec: kcorev1.ServiceSpec{
+			Type:     kcorev1.ServiceTypeClusterIP,
+			Selector: kcmlabel.GetCommonLabels(BackendResourceName),
+			Ports: []kcorev1.ServicePort{
+				{
+					Name:       backendPortName,
+					Protocol:   kcorev1.ProtocolTCP,
+					Port:       backendPortNum,
+					TargetPort: intstr.FromString(backendPortName),
+				},
+				{
+					Name:       backendMetricsPortName,
+					Protocol:   kcorev1.ProtocolTCP,
+					Port:       backendMetricsPortNum,
+					TargetPort: intstr.FromString(backendMetricsPortName),
+				},
+			},
+		},
+	}
+	require.Equal(t, wantService, gotService)
+}
+
+func Test_GetManagedResources(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
 	logger, err := testutils.NewSugaredLogger()
 	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotResources := backendManager.GetManagedResources(givenCompanion)
+
+	// then
+	clusterScopedKinds := []string{"ClusterRole", "ClusterRoleBinding"}
+	gotKinds := make([]string, 0, len(gotResources))
+	for _, resource := range gotResources {
+		kind := resource.GetObjectKind().GroupVersionKind().Kind
+		require.Equal(t, BackendResourceName, resource.GetName())
+		if slices.Contains(clusterScopedKinds, kind) {
+			require.Empty(t, resource.GetNamespace())
+		} else {
+			require.Equal(t, givenCompanion.GetNamespace(), resource.GetNamespace())
+		}
+		gotKinds = append(gotKinds, kind)
+	}
+	require.ElementsMatch(t, []string{
+		"NetworkPolicy", "PodDisruptionBudget", "HorizontalPodAutoscaler", "Service", "Deployment", "Secret",
+		"ClusterRoleBinding", "ClusterRole", "ServiceAccount",
+	}, gotKinds)
+}
 
-	kubeClient := new(kcmk8smocks.Client)
-	backendManager := NewBackendManager(nil, kubeClient, logger)
+func Test_GenerateNewHPA(t *testing.T) {
+	t.Parallel()
+
+	cpuMetric := func(utilization int32) kautoscalingv2.MetricSpec {
+		return kautoscalingv2.MetricSpec{
+			Type: kautoscalingv2.ResourceMetricSourceType,
+			Resource: &kautoscalingv2.ResourceMetricSource{
+				Name: kcorev1.ResourceCPU,
+				Target: kautoscalingv2.MetricTarget{
+					Type:               kautoscalingv2.UtilizationMetricType,
+					AverageUtilization: utils.Int32Ptr(utilization),
+				},
+			},
+		}
+	}
+	memoryMetric := func(utilization int32) kautoscalingv2.MetricSpec {
+		metric := cpuMetric(utilization)
+		metric.Resource.Name = kcorev1.ResourceMemory
+		return metric
+	}
 
-	// define mock behaviour.
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenReplicas   kcmv1alpha1.ReplicasConfig
+		wantMinReplicas int32
+		wantMaxReplicas int32
+		wantMetrics     []kautoscalingv2.MetricSpec
+	}{
+		{
+			name:            "should use the defaults when replicas are not configured",
+			givenReplicas:   kcmv1alpha1.ReplicasConfig{},
+			wantMinReplicas: defaultMinReplicas,
+			wantMaxReplicas: defaultMaxReplicas,
+			wantMetrics:     []kautoscalingv2.MetricSpec{cpuMetric(defaultTargetCPUUtilization)},
+		},
+		{
+			name:            "should use the min and max replicas from the CR",
+			givenReplicas:   kcmv1alpha1.ReplicasConfig{Min: 2, Max: 5},
+			wantMinReplicas: 2,
+			wantMaxReplicas: 5,
+			wantMetrics:     []kautoscalingv2.MetricSpec{cpuMetric(defaultTargetCPUUtilization)},
+		},
+		{
+			name: "should use the CPU and memory utilization targets from the CR",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{
+				Min:                               1,
+				Max:                               4,
+				TargetCPUUtilizationPercentage:    utils.Int32Ptr(60),
+				TargetMemoryUtilizationPercentage: utils.Int32Ptr(70),
+			},
+			wantMinReplicas: 1,
+			wantMaxReplicas: 4,
+			wantMetrics:     []kautoscalingv2.MetricSpec{cpuMetric(60), memoryMetric(70)},
+		},
+		{
+			name: "should only use the memory utilization target when only it is defined",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{
+				Min:                               1,
+				Max:                               4,
+				TargetMemoryUtilizationPercentage: utils.Int32Ptr(70),
+			},
+			wantMinReplicas: 1,
+			wantMaxReplicas: 4,
+			wantMetrics:     []kautoscalingv2.MetricSpec{memoryMetric(70)},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.Replicas = tc.givenReplicas
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+			backendManager := NewBackendManager(nil, nil, logger)
+
+			// when
+			gotHPA, err := backendManager.GenerateNewHPA(givenCompanion)
+
+			// then
+			require.NoError(t, err)
+			wantHPA := &kautoscalingv2.HorizontalPodAutoscaler{
+				TypeMeta: kmetav1.TypeMeta{
+					Kind:       "HorizontalPodAutoscaler",
+					APIVersion: "autoscaling/v2",
+				},
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:            BackendResourceName,
+					Namespace:       givenCompanion.Namespace,
+					Labels:          kcmlabel.GetCommonLabels(BackendResourceName),
+					OwnerReferences: getOwnerReferences(*givenCompanion),
+				},
+				Spec: kautoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: kautoscalingv2.CrossVersionObjectReference{
+						Kind:       "Deployment",
+						Name:       BackendResourceName,
+						APIVersion: "apps/v1",
+					},
+					MinReplicas: utils.Int32Ptr(tc.wantMinReplicas),
+					MaxReplicas: tc.wantMaxReplicas,
+					Metrics:     tc.wantMetrics,
+				},
+			}
+			require.Equal(t, wantHPA, gotHPA)
+		})
+	}
+}
+
+func Test_GetBackendConfig(t *testing.T) {
+	t.Parallel()
+
+	// define sample data.
+	sampleTime := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
 	sampleSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{CreationTimestamp: kmetav1.NewTime(sampleTime)},
 		Data: map[string][]byte{
-			"test-key": []byte("test-value"),
+			"host":         []byte("test.example.com"),
+			"port":         []byte("443"),
+			"user":         []byte("test-user"),
+			"password":     []byte("test-password"),
+			"clientid":     []byte("test-client"),
+			"clientsecret": []byte("test-client-secret"),
+			"url":          []byte("https://auth.example.com"),
+			"serviceurls":  []byte(`{"AI_API_URL":"https://api.example.com"}`),
 		},
 	}
 	sampleConfigMap := &kcorev1.ConfigMap{
 		Data: map[string]string{
-			"test-key": "test-value",
+			"resourceGroup": "default",
 		},
 	}
-	kubeClient.On("GetSecret", mock.Anything, mock.Anything, mock.Anything).Return(
-		sampleSecret, nil).Times(3)
-	kubeClient.On("GetConfigMap", mock.Anything, mock.Anything, mock.Anything).Return(
-		sampleConfigMap, nil).Once()
+	invalidSecret := sampleSecret.DeepCopy()
+	delete(invalidSecret.Data, "password")
+	invalidSecret.Data["port"] = []byte("not-a-port")
+	sampleSecretData, err := json.Marshal(sampleSecret.Data)
+	require.NoError(t, err)
+	sampleConfigData, err := json.Marshal(sampleConfigMap.Data)
+	require.NoError(t, err)
+	notFoundErr := kapierrors.NewNotFound(kcorev1.Resource("secrets"), "test")
 
-	// when
-	gotConfig, err := backendManager.GetBackendConfig(context.TODO())
+	givenCompanion := testutils.NewCompanionCR(
+		testutils.WithHanaCloudSecret("hana", "hana-ns"),
+		testutils.WithRedisSecret("redis", "redis-ns"),
+		testutils.WithAICoreSecret("ai-core", "ai-core-ns"),
+	)
 
-	// then
-	require.NoError(t, err)
-	sampleSecretData := "{\"test-key\":\"dGVzdC12YWx1ZQ==\"}" //nolint:gosec // test data.
-	sampleConfigData := "{\"test-key\":\"test-value\"}"
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(kubeClient *kcmk8smocks.Client)
+		wantConfig              *Config
+		wantError               error
+		wantErrorMessage        string
+	}{
+		{
+			name: "should read the dependencies referenced in the Companion CR",
+			givenMocksBehaviourFunc: func(kubeClient *kcmk8smocks.Client) {
+				kubeClient.On("GetSecret", mock.Anything, "hana", "hana-ns").Return(sampleSecret, nil).Once()
+				kubeClient.On("GetSecret", mock.Anything, "redis", "redis-ns").Return(sampleSecret, nil).Once()
+				kubeClient.On("GetSecret", mock.Anything, "ai-core", "ai-core-ns").Return(sampleSecret, nil).Once()
+				kubeClient.On("GetConfigMap", mock.Anything, "ai-core", "ai-core-ns").Return(
+					sampleConfigMap, nil).Once()
+			},
+			wantConfig: &Config{
+				HanaDB:       sampleSecretData,
+				Redis:        sampleSecretData,
+				AICoreSecret: sampleSecretData,
+				AICoreConfig: sampleConfigData,
+				CredentialsLastModified: map[kcmv1alpha1.SecretSpec]time.Time{
+					{Name: "hana", Namespace: "hana-ns"}:       sampleTime,
+					{Name: "redis", Namespace: "redis-ns"}:     sampleTime,
+					{Name: "ai-core", Namespace: "ai-core-ns"}: sampleTime,
+				},
+			},
+		},
+		{
+			name: "should return ErrDependencyNotFound when a referenced secret is missing",
+			givenMocksBehaviourFunc: func(kubeClient *kcmk8smocks.Client) {
+				kubeClient.On("GetSecret", mock.Anything, "hana", "hana-ns").Return(nil, notFoundErr).Once()
+			},
+			wantError: ErrDependencyNotFound,
+		},
+		{
+			name: "should return ErrDependencyNotFound when the AI Core configMap is missing",
+			givenMocksBehaviourFunc: func(kubeClient *kcmk8smocks.Client) {
+				kubeClient.On("GetSecret", mock.Anything, mock.Anything, mock.Anything).Return(
+					sampleSecret, nil).Times(3)
+				kubeClient.On("GetConfigMap", mock.Anything, "ai-core", "ai-core-ns").Return(
+					nil, notFoundErr).Once()
+			},
+			wantError: ErrDependencyNotFound,
+		},
+		{
+			name: "should return ErrInvalidDependency naming the invalid keys of all dependencies",
+			givenMocksBehaviourFunc: func(kubeClient *kcmk8smocks.Client) {
+				kubeClient.On("GetSecret", mock.Anything, "hana", "hana-ns").Return(invalidSecret, nil).Once()
+				kubeClient.On("GetSecret", mock.Anything, "redis", "redis-ns").Return(sampleSecret, nil).Once()
+				kubeClient.On("GetSecret", mock.Anything, "ai-core", "ai-core-ns").Return(sampleSecret, nil).Once()
+				kubeClient.On("GetConfigMap", mock.Anything, "ai-core", "ai-core-ns").Return(
+					&kcorev1.ConfigMap{}, nil).Once()
+			},
+			wantError: ErrInvalidDependency,
+			wantErrorMessage: "backend dependency is invalid: HANA Cloud secret hana-ns/hana: " +
+				"invalid key \"port\": must be a port number between 1 and 65535, missing key \"password\"; " +
+				"AI Core configMap ai-core-ns/ai-core: missing key \"resourceGroup|resource_group\"",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+
+			kubeClient := new(kcmk8smocks.Client)
+			backendManager := NewBackendManager(nil, kubeClient, logger)
+			tc.givenMocksBehaviourFunc(kubeClient)
 
-	wantConfig := &Config{
-		HanaDB:       []byte(sampleSecretData),
-		Redis:        []byte(sampleSecretData),
-		AICoreSecret: []byte(sampleSecretData),
-		AICoreConfig: []byte(sampleConfigData),
+			// when
+			gotConfig, err := backendManager.GetBackendConfig(context.TODO(), givenCompanion)
+
+			// then
+			if tc.wantError != nil {
+				require.ErrorIs(t, err, tc.wantError)
+				if tc.wantErrorMessage != "" {
+					require.EqualError(t, err, tc.wantErrorMessage)
+				}
+				require.Nil(t, gotConfig)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantConfig, gotConfig)
+			}
+			kubeClient.AssertExpectations(t)
+		})
 	}
+}
 
-	// compare object.
-	require.Equal(t, wantConfig, gotConfig)
-	kubeClient.AssertExpectations(t)
+func Test_GenerateNewPDB(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name               string
+		givenReplicas      kcmv1alpha1.ReplicasConfig
+		wantPDB            bool
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:          "should not create a PodDisruptionBudget when max replicas is 1",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{Min: 1, Max: 1},
+			wantPDB:       false,
+		},
+		{
+			name:               "should allow one unavailable replica by default",
+			givenReplicas:      kcmv1alpha1.ReplicasConfig{Min: 1, Max: 3},
+			wantPDB:            true,
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:               "should use the default max replicas when they are not configured",
+			givenReplicas:      kcmv1alpha1.ReplicasConfig{},
+			wantPDB:            true,
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name: "should use minAvailable from the CR",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{
+				Min: 2,
+				Max: 4,
+				PodDisruptionBudget: &kcmv1alpha1.PodDisruptionBudgetConfig{
+					MinAvailable: ptr.To(intstr.FromString("50%")),
+				},
+			},
+			wantPDB:          true,
+			wantMinAvailable: ptr.To(intstr.FromString("50%")),
+		},
+		{
+			name: "should use maxUnavailable from the CR",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{
+				Min: 2,
+				Max: 4,
+				PodDisruptionBudget: &kcmv1alpha1.PodDisruptionBudgetConfig{
+					MaxUnavailable: ptr.To(intstr.FromInt32(2)),
+				},
+			},
+			wantPDB:            true,
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(2)),
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.Replicas = tc.givenReplicas
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+			backendManager := NewBackendManager(nil, nil, logger)
+
+			// when
+			gotPDB, err := backendManager.GenerateNewPDB(givenCompanion)
+
+			// then
+			require.NoError(t, err)
+			if !tc.wantPDB {
+				require.Nil(t, gotPDB)
+				return
+			}
+			wantPDB := &kpolicyv1.PodDisruptionBudget{
+				TypeMeta: kmetav1.TypeMeta{
+					Kind:       "PodDisruptionBudget",
+					APIVersion: "policy/v1",
+				},
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:            BackendResourceName,
+					Namespace:       givenCompanion.Namespace,
+					Labels:          kcmlabel.GetCommonLabels(BackendResourceName),
+					OwnerReferences: getOwnerReferences(*givenCompanion),
+				},
+				Spec: kpolicyv1.PodDisruptionBudgetSpec{
+					Selector:       kmetav1.SetAsLabelSelector(kcmlabel.GetCommonLabels(BackendResourceName)),
+					MinAvailable:   tc.wantMinAvailable,
+					MaxUnavailable: tc.wantMaxUnavailable,
+				},
+			}
+			require.Equal(t, wantPDB, gotPDB)
+		})
+	}
 }
@@ -3,17 +3,29 @@
 package mocks
 
 import (
-	context "context"
-
 	backendmanager "github.com/kyma-project/kyma-companion-manager/internal/backendmanager"
+	appsv1 "k8s.io/api/apps/v1"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+
+	context "context"
 
 	corev1 "k8s.io/api/core/v1"
 
 	mock "github.com/stretchr/testify/mock"
 
-	v1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	policyv1 "k8s.io/api/policy/v1"
+
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "k8s.io/api/rbac/v1"
 
 	v1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+
+	v2 "k8s.io/api/autoscaling/v2"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -21,29 +33,209 @@ type Manager struct {
 	mock.Mock
 }
 
-// GenerateNewDeployment provides a mock function with given fields: companion, backendImage
-func (_m *Manager) GenerateNewDeployment(companion *v1alpha1.Companion, backendImage string) (*v1.Deployment, error) {
-	ret := _m.Called(companion, backendImage)
+// GenerateNewClusterRole provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewClusterRole(companion *v1alpha1.Companion) (*v1.ClusterRole, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewClusterRole")
+	}
+
+	var r0 *v1.ClusterRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*v1.ClusterRole, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *v1.ClusterRole); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ClusterRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewClusterRoleBinding provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewClusterRoleBinding(companion *v1alpha1.Companion) (*v1.ClusterRoleBinding, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewClusterRoleBinding")
+	}
+
+	var r0 *v1.ClusterRoleBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*v1.ClusterRoleBinding, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *v1.ClusterRoleBinding); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ClusterRoleBinding)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewDeployment provides a mock function with given fields: companion, backendImage, backendSecret
+func (_m *Manager) GenerateNewDeployment(companion *v1alpha1.Companion, backendImage string, backendSecret *corev1.Secret) (*appsv1.Deployment, error) {
+	ret := _m.Called(companion, backendImage, backendSecret)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateNewDeployment")
 	}
 
-	var r0 *v1.Deployment
+	var r0 *appsv1.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, string, *corev1.Secret) (*appsv1.Deployment, error)); ok {
+		return rf(companion, backendImage, backendSecret)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, string, *corev1.Secret) *appsv1.Deployment); ok {
+		r0 = rf(companion, backendImage, backendSecret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appsv1.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion, string, *corev1.Secret) error); ok {
+		r1 = rf(companion, backendImage, backendSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewExposure provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewExposure(companion *v1alpha1.Companion) (map[schema.GroupVersionResource]*unstructured.Unstructured, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewExposure")
+	}
+
+	var r0 map[schema.GroupVersionResource]*unstructured.Unstructured
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (map[schema.GroupVersionResource]*unstructured.Unstructured, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) map[schema.GroupVersionResource]*unstructured.Unstructured); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[schema.GroupVersionResource]*unstructured.Unstructured)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewHPA provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewHPA(companion *v1alpha1.Companion) (*v2.HorizontalPodAutoscaler, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewHPA")
+	}
+
+	var r0 *v2.HorizontalPodAutoscaler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*v2.HorizontalPodAutoscaler, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *v2.HorizontalPodAutoscaler); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2.HorizontalPodAutoscaler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewNetworkPolicy provides a mock function with given fields: companion, backendSecret
+func (_m *Manager) GenerateNewNetworkPolicy(companion *v1alpha1.Companion, backendSecret *corev1.Secret) (*networkingv1.NetworkPolicy, error) {
+	ret := _m.Called(companion, backendSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewNetworkPolicy")
+	}
+
+	var r0 *networkingv1.NetworkPolicy
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, string) (*v1.Deployment, error)); ok {
-		return rf(companion, backendImage)
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, *corev1.Secret) (*networkingv1.NetworkPolicy, error)); ok {
+		return rf(companion, backendSecret)
 	}
-	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, string) *v1.Deployment); ok {
-		r0 = rf(companion, backendImage)
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion, *corev1.Secret) *networkingv1.NetworkPolicy); ok {
+		r0 = rf(companion, backendSecret)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Deployment)
+			r0 = ret.Get(0).(*networkingv1.NetworkPolicy)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion, string) error); ok {
-		r1 = rf(companion, backendImage)
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion, *corev1.Secret) error); ok {
+		r1 = rf(companion, backendSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewPDB provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewPDB(companion *v1alpha1.Companion) (*policyv1.PodDisruptionBudget, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewPDB")
+	}
+
+	var r0 *policyv1.PodDisruptionBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*policyv1.PodDisruptionBudget, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *policyv1.PodDisruptionBudget); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*policyv1.PodDisruptionBudget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,9 +273,69 @@ func (_m *Manager) GenerateNewSecret(companion *v1alpha1.Companion, config backe
 	return r0, r1
 }
 
-// GetBackendConfig provides a mock function with given fields: ctx
-func (_m *Manager) GetBackendConfig(ctx context.Context) (*backendmanager.Config, error) {
-	ret := _m.Called(ctx)
+// GenerateNewService provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewService(companion *v1alpha1.Companion) (*corev1.Service, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewService")
+	}
+
+	var r0 *corev1.Service
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*corev1.Service, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *corev1.Service); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Service)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateNewServiceAccount provides a mock function with given fields: companion
+func (_m *Manager) GenerateNewServiceAccount(companion *v1alpha1.Companion) (*corev1.ServiceAccount, error) {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateNewServiceAccount")
+	}
+
+	var r0 *corev1.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) (*corev1.ServiceAccount, error)); ok {
+		return rf(companion)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) *corev1.ServiceAccount); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.Companion) error); ok {
+		r1 = rf(companion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBackendConfig provides a mock function with given fields: ctx, companion
+func (_m *Manager) GetBackendConfig(ctx context.Context, companion *v1alpha1.Companion) (*backendmanager.Config, error) {
+	ret := _m.Called(ctx, companion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBackendConfig")
@@ -91,19 +343,19 @@ func (_m *Manager) GetBackendConfig(ctx context.Context) (*backendmanager.Config
 
 	var r0 *backendmanager.Config
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*backendmanager.Config, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Companion) (*backendmanager.Config, error)); ok {
+		return rf(ctx, companion)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *backendmanager.Config); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Companion) *backendmanager.Config); ok {
+		r0 = rf(ctx, companion)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*backendmanager.Config)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, *v1alpha1.Companion) error); ok {
+		r1 = rf(ctx, companion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,6 +363,26 @@ func (_m *Manager) GetBackendConfig(ctx context.Context) (*backendmanager.Config
 	return r0, r1
 }
 
+// GetManagedResources provides a mock function with given fields: companion
+func (_m *Manager) GetManagedResources(companion *v1alpha1.Companion) []client.Object {
+	ret := _m.Called(companion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetManagedResources")
+	}
+
+	var r0 []client.Object
+	if rf, ok := ret.Get(0).(func(*v1alpha1.Companion) []client.Object); ok {
+		r0 = rf(companion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Object)
+		}
+	}
+
+	return r0
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
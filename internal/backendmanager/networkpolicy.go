@@ -0,0 +1,213 @@
+package backendmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	kcmk8snetworkpolicy "github.com/kyma-project/kyma-companion-manager/pkg/k8s/networkpolicy"
+)
+
+const (
+	dnsPort      = int32(53)
+	ipv4MaskBits = 32
+	ipv6MaskBits = 128
+)
+
+// defaultEndpointPorts are the ports of the endpoint URLs which do not define a port.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var defaultEndpointPorts = map[string]int32{
+	"http":   80,
+	"https":  443,
+	"redis":  6379,
+	"rediss": 6379,
+}
+
+// endpoint is the host and port of a service which the companion backend connects to.
+// The port is 0 if it is unknown.
+type endpoint struct {
+	host string
+	port int32
+}
+
+// GenerateNewNetworkPolicy returns the NetworkPolicy of the companion backend. It returns nil if the
+// NetworkPolicy is not enabled in the Companion CR. The egress to the HANA and Redis endpoints is derived
+// from the credentials in the given backend secret.
+func (m *BackendManager) GenerateNewNetworkPolicy(companion *kcmv1alpha1.Companion,
+	backendSecret *kcorev1.Secret,
+) (*knetworkingv1.NetworkPolicy, error) {
+	config := companion.Spec.Companion.NetworkPolicy
+	if config == nil || !config.Enabled {
+		return nil, nil //nolint:nilnil // no NetworkPolicy is needed.
+	}
+
+	endpoints, err := getBackendEndpoints(backendSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	egressRules := []knetworkingv1.NetworkPolicyEgressRule{getDNSEgressRule()}
+	for _, e := range endpoints {
+		if rule, ok := getEndpointEgressRule(e); ok {
+			egressRules = append(egressRules, rule)
+		}
+	}
+	egressRules = append(egressRules, withDefaultProtocols(config.Egress)...)
+
+	labels := kcmlabel.GetCommonLabels(BackendResourceName)
+	opts := []kcmk8snetworkpolicy.Opt{
+		kcmk8snetworkpolicy.WithLabels(labels),
+		kcmk8snetworkpolicy.WithOwnerReferences(getOwnerReferences(*companion)),
+		kcmk8snetworkpolicy.WithPodSelector(labels),
+		kcmk8snetworkpolicy.WithEgressRules(egressRules...),
+	}
+	if len(config.IngressFrom) > 0 {
+		opts = append(opts, kcmk8snetworkpolicy.WithIngressRule(config.IngressFrom, []knetworkingv1.NetworkPolicyPort{
+			getNetworkPolicyPort(kcorev1.ProtocolTCP, backendPortNum),
+			getNetworkPolicyPort(kcorev1.ProtocolTCP, backendMetricsPortNum),
+		}))
+	}
+
+	return kcmk8snetworkpolicy.NewNetworkPolicy(BackendResourceName, companion.GetNamespace(), opts...), nil
+}
+
+// getBackendEndpoints returns the distinct HANA and Redis endpoints found in the credentials of the given
+// backend secret. Credentials which do not define an endpoint are skipped.
+func getBackendEndpoints(backendSecret *kcorev1.Secret) ([]endpoint, error) {
+	if backendSecret == nil {
+		return nil, nil
+	}
+
+	var endpoints []endpoint
+	for _, key := range []string{backendSecretKeyHanaDB, backendSecretKeyRedis} {
+		data, ok := backendSecret.Data[key]
+		if !ok || len(data) == 0 {
+			continue
+		}
+		credentials := map[string][]byte{}
+		if err := json.Unmarshal(data, &credentials); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of the backend secret: %w", key, err)
+		}
+		e, ok := getEndpoint(credentials)
+		if !ok || containsEndpoint(endpoints, e) {
+			continue
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, nil
+}
+
+// getEndpoint returns the endpoint defined by the given credentials, either as `url` or as `host` and `port`.
+// The keys are matched case-insensitively. It returns false if no endpoint is defined.
+func getEndpoint(credentials map[string][]byte) (endpoint, bool) {
+	var e endpoint
+	if rawURL := getCredential(credentials, "url", "uri"); rawURL != "" {
+		if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+			e.host = u.Hostname()
+			e.port = parsePort(u.Port())
+			if e.port == 0 {
+				e.port = defaultEndpointPorts[strings.ToLower(u.Scheme)]
+			}
+		}
+	}
+	if host := getCredential(credentials, "host", "hostname"); host != "" {
+		e.host = host
+	}
+	if port := parsePort(getCredential(credentials, "port")); port != 0 {
+		e.port = port
+	}
+	return e, e.host != "" || e.port != 0
+}
+
+// getCredential returns the value of the first of the given keys found in the credentials.
+func getCredential(credentials map[string][]byte, keys ...string) string {
+	for _, key := range keys {
+		for k, v := range credentials {
+			if strings.EqualFold(k, key) {
+				return strings.TrimSpace(string(v))
+			}
+		}
+	}
+	return ""
+}
+
+// parsePort returns the given port as number, or 0 if it is not a valid port.
+func parsePort(port string) int32 {
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0
+	}
+	return int32(p)
+}
+
+func containsEndpoint(endpoints []endpoint, e endpoint) bool {
+	for _, existing := range endpoints {
+		if existing == e {
+			return true
+		}
+	}
+	return false
+}
+
+// getEndpointEgressRule returns the egress rule which allows the traffic to the given endpoint. Hostnames cannot
+// be used in a NetworkPolicy, so the traffic to their port is allowed for all destinations. It returns false
+// if no rule can be derived, i.e. for a hostname without a port.
+func getEndpointEgressRule(e endpoint) (knetworkingv1.NetworkPolicyEgressRule, bool) {
+	rule := knetworkingv1.NetworkPolicyEgressRule{}
+	if ip := net.ParseIP(e.host); ip != nil {
+		maskBits := ipv6MaskBits
+		if ip.To4() != nil {
+			maskBits = ipv4MaskBits
+		}
+		rule.To = []knetworkingv1.NetworkPolicyPeer{
+			{IPBlock: &knetworkingv1.IPBlock{CIDR: fmt.Sprintf("%s/%d", ip.String(), maskBits)}},
+		}
+	}
+	if e.port != 0 {
+		rule.Ports = []knetworkingv1.NetworkPolicyPort{getNetworkPolicyPort(kcorev1.ProtocolTCP, e.port)}
+	}
+	return rule, len(rule.To) > 0 || len(rule.Ports) > 0
+}
+
+// getDNSEgressRule returns the egress rule which allows DNS lookups.
+func getDNSEgressRule() knetworkingv1.NetworkPolicyEgressRule {
+	return knetworkingv1.NetworkPolicyEgressRule{
+		Ports: []knetworkingv1.NetworkPolicyPort{
+			getNetworkPolicyPort(kcorev1.ProtocolUDP, dnsPort),
+			getNetworkPolicyPort(kcorev1.ProtocolTCP, dnsPort),
+		},
+	}
+}
+
+// withDefaultProtocols returns a copy of the given egress rules, in which the ports without a protocol use TCP.
+// The API server defaults the protocol to TCP, so the NetworkPolicy would never be equal to the expected one
+// otherwise.
+func withDefaultProtocols(rules []knetworkingv1.NetworkPolicyEgressRule) []knetworkingv1.NetworkPolicyEgressRule {
+	result := make([]knetworkingv1.NetworkPolicyEgressRule, 0, len(rules))
+	for _, rule := range rules {
+		copied := *rule.DeepCopy()
+		for i := range copied.Ports {
+			if copied.Ports[i].Protocol == nil {
+				copied.Ports[i].Protocol = ptr.To(kcorev1.ProtocolTCP)
+			}
+		}
+		result = append(result, copied)
+	}
+	return result
+}
+
+func getNetworkPolicyPort(protocol kcorev1.Protocol, port int32) knetworkingv1.NetworkPolicyPort {
+	p := intstr.FromInt32(port)
+	return knetworkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p}
+}
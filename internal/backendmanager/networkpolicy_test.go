@@ -0,0 +1,315 @@
+package backendmanager
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	"github.com/kyma-project/kyma-companion-manager/pkg/equality"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+func Test_GenerateNewNetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	newBackendSecret := func(hanaDB, redis map[string][]byte) *kcorev1.Secret {
+		secret := &kcorev1.Secret{Data: map[string][]byte{}}
+		for key, credentials := range map[string]map[string][]byte{
+			backendSecretKeyHanaDB: hanaDB,
+			backendSecretKeyRedis:  redis,
+		} {
+			data, err := json.Marshal(credentials)
+			require.NoError(t, err)
+			secret.Data[key] = data
+		}
+		return secret
+	}
+	givenIngressFrom := []knetworkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &kmetav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kyma-system"},
+			},
+			PodSelector: &kmetav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "busola"},
+			},
+		},
+	}
+	givenEgress := knetworkingv1.NetworkPolicyEgressRule{
+		Ports: []knetworkingv1.NetworkPolicyPort{getNetworkPolicyPort(kcorev1.ProtocolTCP, 8443)},
+	}
+
+	// define test cases
+	testCases := []struct {
+		name               string
+		givenNetworkPolicy *kcmv1alpha1.NetworkPolicyConfig
+		givenBackendSecret *kcorev1.Secret
+		wantNil            bool
+		wantIngress        []knetworkingv1.NetworkPolicyIngressRule
+		wantEgress         []knetworkingv1.NetworkPolicyEgressRule
+		wantError          bool
+	}{
+		{
+			name:               "should not create a NetworkPolicy when it is not configured",
+			givenNetworkPolicy: nil,
+			wantNil:            true,
+		},
+		{
+			name:               "should not create a NetworkPolicy when it is disabled",
+			givenNetworkPolicy: &kcmv1alpha1.NetworkPolicyConfig{Enabled: false, IngressFrom: givenIngressFrom},
+			wantNil:            true,
+		},
+		{
+			name:               "should only allow DNS when no ingress and no endpoints are defined",
+			givenNetworkPolicy: &kcmv1alpha1.NetworkPolicyConfig{Enabled: true},
+			givenBackendSecret: newBackendSecret(nil, nil),
+			wantEgress:         []knetworkingv1.NetworkPolicyEgressRule{getDNSEgressRule()},
+		},
+		{
+			name: "should allow ingress from the given peers and egress to the endpoints and the given rules",
+			givenNetworkPolicy: &kcmv1alpha1.NetworkPolicyConfig{
+				Enabled:     true,
+				IngressFrom: givenIngressFrom,
+				Egress:      []knetworkingv1.NetworkPolicyEgressRule{givenEgress},
+			},
+			givenBackendSecret: newBackendSecret(
+				map[string][]byte{"host": []byte("abc.hana.ondemand.com"), "port": []byte("443")},
+				map[string][]byte{"url": []byte("rediss://10.1.2.3:6380"), "password": []byte("secret")},
+			),
+			wantIngress: []knetworkingv1.NetworkPolicyIngressRule{
+				{
+					From: givenIngressFrom,
+					Ports: []knetworkingv1.NetworkPolicyPort{
+						getNetworkPolicyPort(kcorev1.ProtocolTCP, backendPortNum),
+						getNetworkPolicyPort(kcorev1.ProtocolTCP, backendMetricsPortNum),
+					},
+				},
+			},
+			wantEgress: []knetworkingv1.NetworkPolicyEgressRule{
+				getDNSEgressRule(),
+				{
+					Ports: []knetworkingv1.NetworkPolicyPort{getNetworkPolicyPort(kcorev1.ProtocolTCP, 443)},
+				},
+				{
+					To: []knetworkingv1.NetworkPolicyPeer{
+						{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.1.2.3/32"}},
+					},
+					Ports: []knetworkingv1.NetworkPolicyPort{getNetworkPolicyPort(kcorev1.ProtocolTCP, 6380)},
+				},
+				givenEgress,
+			},
+		},
+		{
+			name: "should use TCP for the ports of the given egress rules without a protocol",
+			givenNetworkPolicy: &kcmv1alpha1.NetworkPolicyConfig{
+				Enabled: true,
+				Egress: []knetworkingv1.NetworkPolicyEgressRule{
+					{Ports: []knetworkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(8443))}}},
+				},
+			},
+			givenBackendSecret: newBackendSecret(nil, nil),
+			wantEgress: []knetworkingv1.NetworkPolicyEgressRule{
+				getDNSEgressRule(),
+				givenEgress,
+			},
+		},
+		{
+			name:               "should return error when the credentials cannot be parsed",
+			givenNetworkPolicy: &kcmv1alpha1.NetworkPolicyConfig{Enabled: true},
+			givenBackendSecret: &kcorev1.Secret{Data: map[string][]byte{backendSecretKeyRedis: []byte("{")}},
+			wantError:          true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.NetworkPolicy = tc.givenNetworkPolicy
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+			backendManager := NewBackendManager(nil, nil, logger)
+
+			// when
+			gotNetworkPolicy, err := backendManager.GenerateNewNetworkPolicy(givenCompanion, tc.givenBackendSecret)
+
+			// then
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantNil {
+				require.Nil(t, gotNetworkPolicy)
+				return
+			}
+			require.NotNil(t, gotNetworkPolicy)
+			require.Equal(t, BackendResourceName, gotNetworkPolicy.Name)
+			require.Equal(t, givenCompanion.Namespace, gotNetworkPolicy.Namespace)
+			require.Equal(t, getOwnerReferences(*givenCompanion), gotNetworkPolicy.OwnerReferences)
+			require.Equal(t, kcmlabel.GetCommonLabels(BackendResourceName), gotNetworkPolicy.Spec.PodSelector.MatchLabels)
+			require.Equal(t, []knetworkingv1.PolicyType{knetworkingv1.PolicyTypeIngress, knetworkingv1.PolicyTypeEgress},
+				gotNetworkPolicy.Spec.PolicyTypes)
+			require.Equal(t, tc.wantIngress, gotNetworkPolicy.Spec.Ingress)
+			require.Equal(t, tc.wantEgress, gotNetworkPolicy.Spec.Egress)
+		})
+	}
+}
+
+func Test_GenerateNewNetworkPolicy_EqualToDefaultedNetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	givenCompanion.Spec.Companion.NetworkPolicy = &kcmv1alpha1.NetworkPolicyConfig{
+		Enabled: true,
+		Egress: []knetworkingv1.NetworkPolicyEgressRule{
+			{Ports: []knetworkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(8443))}}},
+		},
+	}
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// the existing NetworkPolicy is stored with the protocol defaulted by the API server.
+	generated, err := backendManager.GenerateNewNetworkPolicy(givenCompanion, nil)
+	require.NoError(t, err)
+	givenExisting := generated.DeepCopy()
+	for _, rule := range givenExisting.Spec.Egress {
+		for i := range rule.Ports {
+			rule.Ports[i].Protocol = ptr.To(ptr.Deref(rule.Ports[i].Protocol, kcorev1.ProtocolTCP))
+		}
+	}
+
+	// when
+	gotNetworkPolicy, err := backendManager.GenerateNewNetworkPolicy(givenCompanion, nil)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, equality.Semantic.DeepEqual(givenExisting, gotNetworkPolicy))
+}
+
+func Test_getEndpoint(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name             string
+		givenCredentials map[string][]byte
+		wantEndpoint     endpoint
+		wantFound        bool
+	}{
+		{
+			name:             "should return the host and port",
+			givenCredentials: map[string][]byte{"host": []byte("redis.example.com"), "port": []byte("6379")},
+			wantEndpoint:     endpoint{host: "redis.example.com", port: 6379},
+			wantFound:        true,
+		},
+		{
+			name:             "should match the keys case-insensitively",
+			givenCredentials: map[string][]byte{"HOST": []byte("10.0.0.1"), "Port": []byte("30015")},
+			wantEndpoint:     endpoint{host: "10.0.0.1", port: 30015},
+			wantFound:        true,
+		},
+		{
+			name:             "should return the host and port of the url",
+			givenCredentials: map[string][]byte{"url": []byte("https://abc.hana.ondemand.com:8443/path")},
+			wantEndpoint:     endpoint{host: "abc.hana.ondemand.com", port: 8443},
+			wantFound:        true,
+		},
+		{
+			name:             "should use the default port of the url scheme",
+			givenCredentials: map[string][]byte{"uri": []byte("redis://redis.example.com")},
+			wantEndpoint:     endpoint{host: "redis.example.com", port: 6379},
+			wantFound:        true,
+		},
+		{
+			name:             "should ignore an invalid port",
+			givenCredentials: map[string][]byte{"host": []byte("redis.example.com"), "port": []byte("abc")},
+			wantEndpoint:     endpoint{host: "redis.example.com"},
+			wantFound:        true,
+		},
+		{
+			name:             "should return false when no endpoint is defined",
+			givenCredentials: map[string][]byte{"user": []byte("admin"), "password": []byte("secret")},
+			wantFound:        false,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotEndpoint, gotFound := getEndpoint(tc.givenCredentials)
+
+			// then
+			require.Equal(t, tc.wantFound, gotFound)
+			require.Equal(t, tc.wantEndpoint, gotEndpoint)
+		})
+	}
+}
+
+func Test_getEndpointEgressRule(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenEndpoint endpoint
+		wantRule      knetworkingv1.NetworkPolicyEgressRule
+		wantFound     bool
+	}{
+		{
+			name:          "should allow the port for all destinations of a hostname",
+			givenEndpoint: endpoint{host: "redis.example.com", port: 6379},
+			wantRule: knetworkingv1.NetworkPolicyEgressRule{
+				Ports: []knetworkingv1.NetworkPolicyPort{getNetworkPolicyPort(kcorev1.ProtocolTCP, 6379)},
+			},
+			wantFound: true,
+		},
+		{
+			name:          "should allow all ports of an IPv6 address without port",
+			givenEndpoint: endpoint{host: "fd00::1"},
+			wantRule: knetworkingv1.NetworkPolicyEgressRule{
+				To: []knetworkingv1.NetworkPolicyPeer{{IPBlock: &knetworkingv1.IPBlock{CIDR: "fd00::1/128"}}},
+			},
+			wantFound: true,
+		},
+		{
+			name:          "should return false for a hostname without port",
+			givenEndpoint: endpoint{host: "redis.example.com"},
+			wantFound:     false,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotRule, gotFound := getEndpointEgressRule(tc.givenEndpoint)
+
+			// then
+			require.Equal(t, tc.wantFound, gotFound)
+			if tc.wantFound {
+				require.Equal(t, tc.wantRule, gotRule)
+			}
+		})
+	}
+}
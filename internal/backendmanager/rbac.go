@@ -0,0 +1,97 @@
+package backendmanager
+
+import (
+	kcorev1 "k8s.io/api/core/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	kcmk8srbac "github.com/kyma-project/kyma-companion-manager/pkg/k8s/rbac"
+	kcmk8sserviceaccount "github.com/kyma-project/kyma-companion-manager/pkg/k8s/serviceaccount"
+)
+
+// readOnlyVerbs are the only verbs which are granted to the companion backend.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var readOnlyVerbs = []string{"get", "list", "watch"}
+
+// defaultClusterAccess is the read access of the companion backend, if the Companion CR does not define one.
+// It intentionally does not include secrets and configmaps, as they may contain credentials.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var defaultClusterAccess = []kcmv1alpha1.ReadOnlyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{
+			"pods", "pods/log", "services", "endpoints", "events", "namespaces", "nodes",
+			"persistentvolumeclaims", "persistentvolumes", "serviceaccounts",
+		},
+	},
+	{
+		APIGroups: []string{"apps"},
+		Resources: []string{"deployments", "statefulsets", "daemonsets", "replicasets"},
+	},
+	{
+		APIGroups: []string{"batch"},
+		Resources: []string{"jobs", "cronjobs"},
+	},
+	{
+		APIGroups: []string{"autoscaling"},
+		Resources: []string{"horizontalpodautoscalers"},
+	},
+	{
+		APIGroups: []string{"networking.k8s.io"},
+		Resources: []string{"ingresses", "networkpolicies"},
+	},
+	{
+		APIGroups: []string{"events.k8s.io"},
+		Resources: []string{"events"},
+	},
+}
+
+// GenerateNewServiceAccount returns the ServiceAccount which is used by the pods of the companion backend.
+func (m *BackendManager) GenerateNewServiceAccount(companion *kcmv1alpha1.Companion,
+) (*kcorev1.ServiceAccount, error) {
+	serviceAccount := kcmk8sserviceaccount.NewServiceAccount(
+		BackendResourceName,
+		companion.GetNamespace(),
+		kcmk8sserviceaccount.WithLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+		kcmk8sserviceaccount.WithOwnerReferences(getOwnerReferences(*companion)),
+	)
+
+	return serviceAccount, nil
+}
+
+// GenerateNewClusterRole returns the ClusterRole which grants the companion backend read access to the
+// resources of the cluster. As a cluster-scoped resource cannot be owned by the Companion CR, it is only
+// tracked by its labels and deleted together with the other managed resources.
+func (m *BackendManager) GenerateNewClusterRole(companion *kcmv1alpha1.Companion,
+) (*krbacv1.ClusterRole, error) {
+	clusterAccess := companion.Spec.Companion.ClusterAccess
+	if len(clusterAccess) == 0 {
+		clusterAccess = defaultClusterAccess
+	}
+
+	opts := []kcmk8srbac.ClusterRoleOpt{
+		kcmk8srbac.WithClusterRoleLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+	}
+	for _, rule := range clusterAccess {
+		opts = append(opts, kcmk8srbac.WithRule(rule.APIGroups, rule.Resources, readOnlyVerbs))
+	}
+
+	return kcmk8srbac.NewClusterRole(BackendResourceName, opts...), nil
+}
+
+// GenerateNewClusterRoleBinding returns the ClusterRoleBinding which binds the ClusterRole of the companion
+// backend to its ServiceAccount.
+func (m *BackendManager) GenerateNewClusterRoleBinding(companion *kcmv1alpha1.Companion,
+) (*krbacv1.ClusterRoleBinding, error) {
+	clusterRoleBinding := kcmk8srbac.NewClusterRoleBinding(
+		BackendResourceName,
+		kcmk8srbac.WithClusterRoleBindingLabels(kcmlabel.GetCommonLabels(BackendResourceName)),
+		kcmk8srbac.WithClusterRoleRef(BackendResourceName),
+		kcmk8srbac.WithServiceAccountSubject(BackendResourceName, companion.GetNamespace()),
+	)
+
+	return clusterRoleBinding, nil
+}
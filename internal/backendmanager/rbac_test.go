@@ -0,0 +1,150 @@
+package backendmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmlabel "github.com/kyma-project/kyma-companion-manager/internal/label"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+func Test_GenerateNewServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotServiceAccount, err := backendManager.GenerateNewServiceAccount(givenCompanion)
+
+	// then
+	require.NoError(t, err)
+	wantServiceAccount := &kcorev1.ServiceAccount{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:            BackendResourceName,
+			Namespace:       givenCompanion.Namespace,
+			Labels:          kcmlabel.GetCommonLabels(BackendResourceName),
+			OwnerReferences: getOwnerReferences(*givenCompanion),
+		},
+	}
+	require.Equal(t, wantServiceAccount, gotServiceAccount)
+}
+
+func Test_GenerateNewClusterRole(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name               string
+		givenClusterAccess []kcmv1alpha1.ReadOnlyRule
+		wantRules          []krbacv1.PolicyRule
+	}{
+		{
+			name:               "should grant the default read access when no cluster access is configured",
+			givenClusterAccess: nil,
+			wantRules: func() []krbacv1.PolicyRule {
+				rules := make([]krbacv1.PolicyRule, 0, len(defaultClusterAccess))
+				for _, rule := range defaultClusterAccess {
+					rules = append(rules, krbacv1.PolicyRule{
+						APIGroups: rule.APIGroups,
+						Resources: rule.Resources,
+						Verbs:     []string{"get", "list", "watch"},
+					})
+				}
+				return rules
+			}(),
+		},
+		{
+			name: "should only grant read access to the configured resources",
+			givenClusterAccess: []kcmv1alpha1.ReadOnlyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods"}},
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets"}},
+			},
+			wantRules: []krbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+				{
+					APIGroups: []string{"apps"},
+					Resources: []string{"deployments", "statefulsets"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.ClusterAccess = tc.givenClusterAccess
+			logger, err := testutils.NewSugaredLogger()
+			require.NoError(t, err)
+			backendManager := NewBackendManager(nil, nil, logger)
+
+			// when
+			gotClusterRole, err := backendManager.GenerateNewClusterRole(givenCompanion)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, BackendResourceName, gotClusterRole.Name)
+			require.Empty(t, gotClusterRole.Namespace)
+			require.Empty(t, gotClusterRole.OwnerReferences)
+			require.Equal(t, kcmlabel.GetCommonLabels(BackendResourceName), gotClusterRole.Labels)
+			require.Equal(t, tc.wantRules, gotClusterRole.Rules)
+		})
+	}
+}
+
+func Test_GenerateNewClusterRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	logger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+	backendManager := NewBackendManager(nil, nil, logger)
+
+	// when
+	gotClusterRoleBinding, err := backendManager.GenerateNewClusterRoleBinding(givenCompanion)
+
+	// then
+	require.NoError(t, err)
+	wantClusterRoleBinding := &krbacv1.ClusterRoleBinding{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:   BackendResourceName,
+			Labels: kcmlabel.GetCommonLabels(BackendResourceName),
+		},
+		RoleRef: krbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     BackendResourceName,
+		},
+		Subjects: []krbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      BackendResourceName,
+				Namespace: givenCompanion.Namespace,
+			},
+		},
+	}
+	require.Equal(t, wantClusterRoleBinding, gotClusterRoleBinding)
+}
@@ -0,0 +1,214 @@
+package backendmanager
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+)
+
+// ErrInvalidDependency is returned when a secret or configMap referenced in the Companion CR does not have the
+// structure expected by the companion backend.
+var ErrInvalidDependency = errors.New("backend dependency is invalid")
+
+// keyRule describes a single key of a dependency. A key can be provided under any of its names, which are
+// matched case-insensitively. Empty values are treated as missing.
+type keyRule struct {
+	names    []string
+	required bool
+	// validate checks the format of the value. The returned error must not contain the value.
+	validate func(value string) error
+}
+
+// dependencySchema describes the keys which the companion backend expects in a dependency.
+type dependencySchema struct {
+	kind string
+	keys []keyRule
+}
+
+// The schemas of the dependencies referenced in the Companion CR. They follow the structure of the service
+// bindings of the respective SAP BTP services.
+//
+//nolint:gochecknoglobals // used as constant.
+var (
+	hanaDBSchema = dependencySchema{
+		kind: "HANA Cloud secret",
+		keys: []keyRule{
+			{names: []string{"host", "hostname"}, required: true, validate: validateHost},
+			{names: []string{"port"}, required: true, validate: validatePort},
+			{names: []string{"user", "username"}, required: true},
+			{names: []string{"password"}, required: true},
+			{names: []string{"certificate"}, validate: validateCertificate},
+		},
+	}
+	redisSchema = dependencySchema{
+		kind: "Redis secret",
+		keys: []keyRule{
+			{names: []string{"host", "hostname"}, required: true, validate: validateHost},
+			{names: []string{"port"}, required: true, validate: validatePort},
+			{names: []string{"password"}, required: true},
+			{names: []string{"ca_cert", "certificate"}, validate: validateCertificate},
+		},
+	}
+	aiCoreSecretSchema = dependencySchema{
+		kind: "AI Core secret",
+		keys: []keyRule{
+			{names: []string{"clientid"}, required: true},
+			{names: []string{"clientsecret"}, required: true},
+			{names: []string{"url"}, required: true, validate: validateURL},
+			{names: []string{"serviceurls"}, required: true, validate: validateServiceURLs},
+		},
+	}
+	aiCoreConfigSchema = dependencySchema{
+		kind: "AI Core configMap",
+		keys: []keyRule{
+			{names: []string{"resourceGroup", "resource_group"}, required: true},
+		},
+	}
+)
+
+// validate checks the given data against the schema. It returns a description of each missing or invalid key,
+// which never contains the values of the keys.
+func (s dependencySchema) validate(data map[string]string) []string {
+	var problems []string
+	for _, rule := range s.keys {
+		value := lookupKey(data, rule.names)
+		if value == "" {
+			if rule.required {
+				problems = append(problems, fmt.Sprintf("missing key %q", strings.Join(rule.names, "|")))
+			}
+			continue
+		}
+		if rule.validate == nil {
+			continue
+		}
+		if err := rule.validate(value); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid key %q: %s", strings.Join(rule.names, "|"), err))
+		}
+	}
+	return problems
+}
+
+// dependency is a secret or configMap referenced in the Companion CR together with its schema.
+type dependency struct {
+	schema    dependencySchema
+	namespace string
+	name      string
+	data      map[string]string
+}
+
+func newDependency(schema dependencySchema, secretSpec kcmv1alpha1.SecretSpec,
+	data map[string]string,
+) dependency {
+	return dependency{schema: schema, namespace: secretSpec.Namespace, name: secretSpec.Name, data: data}
+}
+
+// validateDependencies checks the data of the given dependencies against their schemas and returns an
+// ErrInvalidDependency naming all missing or invalid keys.
+func validateDependencies(dependencies ...dependency) error {
+	var invalid []string
+	for _, d := range dependencies {
+		if problems := d.schema.validate(d.data); len(problems) > 0 {
+			invalid = append(invalid, fmt.Sprintf("%s %s/%s: %s", d.schema.kind, d.namespace, d.name,
+				strings.Join(problems, ", ")))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidDependency, strings.Join(invalid, "; "))
+}
+
+// secretDataToStrings returns the data of a secret as strings, so that it can be validated like configMap data.
+func secretDataToStrings(data map[string][]byte) map[string]string {
+	result := make(map[string]string, len(data))
+	for k, v := range data {
+		result[k] = string(v)
+	}
+	return result
+}
+
+// lookupKey returns the trimmed value of the first of the given names found in the data.
+func lookupKey(data map[string]string, names []string) string {
+	for _, name := range names {
+		for k, v := range data {
+			if strings.EqualFold(k, name) {
+				return strings.TrimSpace(v)
+			}
+		}
+	}
+	return ""
+}
+
+func validateHost(value string) error {
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+	if len(validation.IsDNS1123Subdomain(strings.ToLower(value))) > 0 {
+		return errors.New("must be a hostname or an IP address")
+	}
+	return nil
+}
+
+func validatePort(value string) error {
+	if parsePort(value) == 0 {
+		return errors.New("must be a port number between 1 and 65535")
+	}
+	return nil
+}
+
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateServiceURLs checks that the value is a JSON object which contains the URL of the AI API.
+func validateServiceURLs(value string) error {
+	var serviceURLs map[string]string
+	if err := json.Unmarshal([]byte(value), &serviceURLs); err != nil {
+		return errors.New("must be a JSON object of service URLs")
+	}
+	if serviceURLs["AI_API_URL"] == "" {
+		return errors.New("must contain the AI_API_URL")
+	}
+	if err := validateURL(serviceURLs["AI_API_URL"]); err != nil {
+		return fmt.Errorf("AI_API_URL %w", err)
+	}
+	return nil
+}
+
+// validateCertificate checks that the value contains at least one PEM encoded certificate and that all
+// certificates can be parsed.
+func validateCertificate(value string) error {
+	rest := []byte(value)
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return errors.New("contains a certificate which cannot be parsed")
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("must contain a PEM encoded certificate")
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package backendmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dependencySchema_validate(t *testing.T) {
+	t.Parallel()
+
+	givenCertificate := newTestCertificatePEM(t)
+
+	// define test cases
+	testCases := []struct {
+		name         string
+		givenSchema  dependencySchema
+		givenData    map[string]string
+		wantProblems []string
+	}{
+		{
+			name:        "should accept a valid HANA Cloud secret",
+			givenSchema: hanaDBSchema,
+			givenData: map[string]string{
+				"host":        "abc.hana.prod-eu10.hanacloud.ondemand.com",
+				"port":        "443",
+				"user":        "test-user",
+				"password":    "test-password",
+				"certificate": givenCertificate,
+			},
+		},
+		{
+			name:        "should match the keys case-insensitively and accept their alternative names",
+			givenSchema: redisSchema,
+			givenData: map[string]string{
+				"HostName": "10.0.0.1",
+				"Port":     "6379",
+				"PASSWORD": "test-password",
+			},
+		},
+		{
+			name:        "should report all missing keys and treat empty values as missing",
+			givenSchema: hanaDBSchema,
+			givenData: map[string]string{
+				"host":     "test.example.com",
+				"password": "  ",
+			},
+			wantProblems: []string{
+				`missing key "port"`,
+				`missing key "user|username"`,
+				`missing key "password"`,
+			},
+		},
+		{
+			name:        "should report invalid hosts, ports and certificates",
+			givenSchema: redisSchema,
+			givenData: map[string]string{
+				"host":     "https://test.example.com",
+				"port":     "70000",
+				"password": "test-password",
+				"ca_cert":  "not-a-certificate",
+			},
+			wantProblems: []string{
+				`invalid key "host|hostname": must be a hostname or an IP address`,
+				`invalid key "port": must be a port number between 1 and 65535`,
+				`invalid key "ca_cert|certificate": must contain a PEM encoded certificate`,
+			},
+		},
+		{
+			name:        "should accept a valid AI Core secret",
+			givenSchema: aiCoreSecretSchema,
+			givenData: map[string]string{
+				"clientid":     "test-client",
+				"clientsecret": "test-client-secret",
+				"url":          "https://auth.example.com",
+				"serviceurls":  `{"AI_API_URL":"https://api.example.com"}`,
+			},
+		},
+		{
+			name:        "should report an invalid URL and service URLs without the AI API URL",
+			givenSchema: aiCoreSecretSchema,
+			givenData: map[string]string{
+				"clientid":     "test-client",
+				"clientsecret": "test-client-secret",
+				"url":          "auth.example.com",
+				"serviceurls":  `{"OTHER_URL":"https://api.example.com"}`,
+			},
+			wantProblems: []string{
+				`invalid key "url": must be an absolute http or https URL`,
+				`invalid key "serviceurls": must contain the AI_API_URL`,
+			},
+		},
+		{
+			name:        "should report service URLs which are not a JSON object",
+			givenSchema: aiCoreSecretSchema,
+			givenData: map[string]string{
+				"clientid":     "test-client",
+				"clientsecret": "test-client-secret",
+				"url":          "https://auth.example.com",
+				"serviceurls":  "https://api.example.com",
+			},
+			wantProblems: []string{
+				`invalid key "serviceurls": must be a JSON object of service URLs`,
+			},
+		},
+		{
+			name:         "should report a missing resource group in the AI Core configMap",
+			givenSchema:  aiCoreConfigSchema,
+			givenData:    map[string]string{},
+			wantProblems: []string{`missing key "resourceGroup|resource_group"`},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotProblems := tc.givenSchema.validate(tc.givenData)
+
+			// then
+			require.Equal(t, tc.wantProblems, gotProblems)
+			for _, problem := range gotProblems {
+				for _, value := range tc.givenData {
+					if len(value) > 4 {
+						require.NotContains(t, problem, value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_validateCertificate(t *testing.T) {
+	t.Parallel()
+
+	givenCertificate := newTestCertificatePEM(t)
+
+	// define test cases
+	testCases := []struct {
+		name       string
+		givenValue string
+		wantError  string
+	}{
+		{
+			name:       "should accept a PEM encoded certificate",
+			givenValue: givenCertificate,
+		},
+		{
+			name:       "should accept a certificate chain",
+			givenValue: givenCertificate + givenCertificate,
+		},
+		{
+			name:       "should reject a value without certificate",
+			givenValue: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})),
+			wantError:  "must contain a PEM encoded certificate",
+		},
+		{
+			name:       "should reject a certificate which cannot be parsed",
+			givenValue: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")})),
+			wantError:  "contains a certificate which cannot be parsed",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			err := validateCertificate(tc.givenValue)
+
+			// then
+			if tc.wantError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantError)
+			}
+		})
+	}
+}
+
+// newTestCertificatePEM returns a self-signed PEM encoded certificate.
+func newTestCertificatePEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -1,6 +1,11 @@
 package backendmanager
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
 	kcorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,7 +30,7 @@ func getContainerPorts() []kcorev1.ContainerPort {
 	}
 }
 
-func getPodSecurityContext() *kcorev1.PodSecurityContext { //nolint: unused // It will be used in the future.
+func getPodSecurityContext() *kcorev1.PodSecurityContext {
 	const id = 10001
 	return &kcorev1.PodSecurityContext{
 		FSGroup:      kcmutils.Int64Ptr(id),
@@ -38,17 +43,40 @@ func getPodSecurityContext() *kcorev1.PodSecurityContext { //nolint: unused // I
 	}
 }
 
-func getContainerSecurityContext() *kcorev1.SecurityContext { //nolint: unused // It will be used in the future.
+// getContainerSecurityContext returns the security context of the companion backend container.
+// The root filesystem is read-only unless writableRootFilesystem is true.
+func getContainerSecurityContext(writableRootFilesystem bool) *kcorev1.SecurityContext {
 	return &kcorev1.SecurityContext{
 		Privileged:               kcmutils.BoolPtr(false),
 		AllowPrivilegeEscalation: kcmutils.BoolPtr(false),
 		RunAsNonRoot:             kcmutils.BoolPtr(true),
+		ReadOnlyRootFilesystem:   kcmutils.BoolPtr(!writableRootFilesystem),
 		Capabilities: &kcorev1.Capabilities{
 			Drop: []kcorev1.Capability{"ALL"},
 		},
 	}
 }
 
+// getTopologySpreadConstraints returns the given topology spread constraints. If none are given,
+// the pods with the given labels are spread across zones. Pods are still scheduled if the cluster
+// has a single zone only.
+func getTopologySpreadConstraints(constraints []kcorev1.TopologySpreadConstraint,
+	labels map[string]string,
+) []kcorev1.TopologySpreadConstraint {
+	if len(constraints) > 0 {
+		return constraints
+	}
+
+	return []kcorev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           zoneSpreadMaxSkew,
+			TopologyKey:       kcorev1.LabelTopologyZone,
+			WhenUnsatisfiable: kcorev1.ScheduleAnyway,
+			LabelSelector:     kmetav1.SetAsLabelSelector(labels),
+		},
+	}
+}
+
 func getReadinessProbe() *kcorev1.Probe {
 	const (
 		readyPath  = "/readyz"
@@ -90,7 +118,7 @@ func getLivenessProbe() *kcorev1.Probe {
 	}
 }
 
-func getResources(requestsCPU, requestsMemory, limitsCPU, limitsMemory string) kcorev1.ResourceRequirements {
+func getDefaultResources() kcorev1.ResourceRequirements {
 	return kcorev1.ResourceRequirements{
 		Requests: kcorev1.ResourceList{
 			kcorev1.ResourceCPU:    resource.MustParse(requestsCPU),
@@ -103,6 +131,77 @@ func getResources(requestsCPU, requestsMemory, limitsCPU, limitsMemory string) k
 	}
 }
 
+// getResources returns the resource requirements of the backend container from the given config.
+// Requests and limits which are not set in the config fall back to the defaults. A defaulted request
+// is lowered to the limit from the config, so that the defaults never make the requirements invalid.
+func getResources(resources kcorev1.ResourceRequirements) kcorev1.ResourceRequirements {
+	result := getDefaultResources()
+	for name, limit := range resources.Limits {
+		result.Limits[name] = limit.DeepCopy()
+	}
+	for name, request := range result.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			result.Requests[name] = limit.DeepCopy()
+		}
+	}
+	for name, request := range resources.Requests {
+		result.Requests[name] = request.DeepCopy()
+	}
+	return result
+}
+
+// getMinMaxReplicas returns the min and max replicas from the given config.
+// It falls back to the defaults for values which are not set, and ensures that max is not lower than min.
+func getMinMaxReplicas(replicas kcmv1alpha1.ReplicasConfig) (int32, int32) {
+	minReplicas, maxReplicas := defaultMinReplicas, defaultMaxReplicas
+	if replicas.Min > 0 {
+		minReplicas = int32(replicas.Min)
+	}
+	if replicas.Max > 0 {
+		maxReplicas = int32(replicas.Max)
+	}
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+	return minReplicas, maxReplicas
+}
+
+// getSecretChecksum returns the SHA-256 checksum of the data of the given secret.
+// The keys are sorted, so that the checksum does not depend on the order of the map.
+func getSecretChecksum(secret *kcorev1.Secret) string {
+	if secret == nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		// separate keys and values, so that moving bytes between them changes the checksum.
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// getLastModifiedTime returns the time of the last modification of the given object. It is the latest time
+// of its creation and of the operations recorded in its managed fields.
+func getLastModifiedTime(obj kmetav1.Object) time.Time {
+	lastModified := obj.GetCreationTimestamp().Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(lastModified) {
+			lastModified = entry.Time.Time
+		}
+	}
+	return lastModified
+}
+
 func getOwnerReferences(companion kcmv1alpha1.Companion) []kmetav1.OwnerReference {
 	return []kmetav1.OwnerReference{
 		{
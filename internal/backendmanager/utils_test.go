@@ -2,9 +2,14 @@ package backendmanager
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 )
 
 func Test_getContainerPorts(t *testing.T) {
@@ -26,3 +31,305 @@ func Test_getContainerPorts(t *testing.T) {
 	}
 	require.Equal(t, want, got)
 }
+
+func Test_getContainerSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                        string
+		givenWritableRootFilesystem bool
+		wantReadOnlyRootFilesystem  bool
+	}{
+		{
+			name:                        "should use a read-only root filesystem by default",
+			givenWritableRootFilesystem: false,
+			wantReadOnlyRootFilesystem:  true,
+		},
+		{
+			name:                        "should use a writable root filesystem if requested",
+			givenWritableRootFilesystem: true,
+			wantReadOnlyRootFilesystem:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := getContainerSecurityContext(tc.givenWritableRootFilesystem)
+
+			// then
+			require.NotNil(t, got.ReadOnlyRootFilesystem)
+			require.Equal(t, tc.wantReadOnlyRootFilesystem, *got.ReadOnlyRootFilesystem)
+			require.False(t, *got.AllowPrivilegeEscalation)
+			require.True(t, *got.RunAsNonRoot)
+			require.Equal(t, []kcorev1.Capability{"ALL"}, got.Capabilities.Drop)
+		})
+	}
+}
+
+func Test_getTopologySpreadConstraints(t *testing.T) {
+	t.Parallel()
+
+	givenLabels := map[string]string{"app": "companion"}
+	givenConstraints := []kcorev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           2,
+			TopologyKey:       kcorev1.LabelHostname,
+			WhenUnsatisfiable: kcorev1.DoNotSchedule,
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		givenConstraints []kcorev1.TopologySpreadConstraint
+		want             []kcorev1.TopologySpreadConstraint
+	}{
+		{
+			name:             "should spread across zones if no constraints are given",
+			givenConstraints: nil,
+			want: []kcorev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: kcorev1.ScheduleAnyway,
+					LabelSelector:     kmetav1.SetAsLabelSelector(givenLabels),
+				},
+			},
+		},
+		{
+			name:             "should return the given constraints",
+			givenConstraints: givenConstraints,
+			want:             givenConstraints,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := getTopologySpreadConstraints(tc.givenConstraints, givenLabels)
+
+			// then
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_getMinMaxReplicas(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenReplicas kcmv1alpha1.ReplicasConfig
+		wantMin       int32
+		wantMax       int32
+	}{
+		{
+			name:          "should return the defaults when replicas are not set",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{},
+			wantMin:       defaultMinReplicas,
+			wantMax:       defaultMaxReplicas,
+		},
+		{
+			name:          "should return the given replicas",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{Min: 2, Max: 6},
+			wantMin:       2,
+			wantMax:       6,
+		},
+		{
+			name:          "should not return max lower than min",
+			givenReplicas: kcmv1alpha1.ReplicasConfig{Min: 5, Max: 2},
+			wantMin:       5,
+			wantMax:       5,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotMin, gotMax := getMinMaxReplicas(tc.givenReplicas)
+
+			// then
+			require.Equal(t, tc.wantMin, gotMin)
+			require.Equal(t, tc.wantMax, gotMax)
+		})
+	}
+}
+
+func Test_getResources(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name           string
+		givenResources kcorev1.ResourceRequirements
+		wantResources  kcorev1.ResourceRequirements
+	}{
+		{
+			name:           "should return the defaults when no resources are set",
+			givenResources: kcorev1.ResourceRequirements{},
+			wantResources:  getDefaultResources(),
+		},
+		{
+			name: "should return the given resources",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("500m"),
+					kcorev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("4"),
+					kcorev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			wantResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("500m"),
+					kcorev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("4"),
+					kcorev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+		{
+			name: "should fall back to the defaults for resources which are not set",
+			givenResources: kcorev1.ResourceRequirements{
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			wantResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse(requestsCPU),
+					kcorev1.ResourceMemory: resource.MustParse(requestsMemory),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse(limitsCPU),
+					kcorev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "should lower a defaulted request to the given limit",
+			givenResources: kcorev1.ResourceRequirements{
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU: resource.MustParse("100m"),
+				},
+			},
+			wantResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("100m"),
+					kcorev1.ResourceMemory: resource.MustParse(requestsMemory),
+				},
+				Limits: kcorev1.ResourceList{
+					kcorev1.ResourceCPU:    resource.MustParse("100m"),
+					kcorev1.ResourceMemory: resource.MustParse(limitsMemory),
+				},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := getResources(tc.givenResources)
+
+			// then
+			require.Equal(t, tc.wantResources, got)
+		})
+	}
+}
+
+func Test_getSecretChecksum(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenSecret := &kcorev1.Secret{
+		Data: map[string][]byte{
+			"hana-db-secret": []byte("hana"),
+			"redis-secret":   []byte("redis"),
+		},
+	}
+
+	// when
+	got := getSecretChecksum(givenSecret)
+
+	// then
+	require.Len(t, got, 64)
+	require.Equal(t, got, getSecretChecksum(givenSecret.DeepCopy()))
+	require.Empty(t, getSecretChecksum(nil))
+
+	changedSecret := givenSecret.DeepCopy()
+	changedSecret.Data["redis-secret"] = []byte("rotated")
+	require.NotEqual(t, got, getSecretChecksum(changedSecret))
+
+	// moving bytes between key and value must change the checksum.
+	movedSecret := &kcorev1.Secret{
+		Data: map[string][]byte{
+			"hana-db-secreth": []byte("ana"),
+			"redis-secret":    []byte("redis"),
+		},
+	}
+	require.NotEqual(t, got, getSecretChecksum(movedSecret))
+}
+
+func Test_getLastModifiedTime(t *testing.T) {
+	t.Parallel()
+
+	creationTime := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	updateTime := creationTime.Add(time.Hour)
+
+	// define test cases
+	testCases := []struct {
+		name         string
+		givenFields  []kmetav1.ManagedFieldsEntry
+		wantModified time.Time
+	}{
+		{
+			name:         "should return the creation time when there are no managed fields",
+			wantModified: creationTime,
+		},
+		{
+			name: "should return the time of the latest operation in the managed fields",
+			givenFields: []kmetav1.ManagedFieldsEntry{
+				{Manager: "kubectl", Time: &kmetav1.Time{Time: updateTime}},
+				{Manager: "other", Time: &kmetav1.Time{Time: creationTime.Add(time.Minute)}},
+				{Manager: "without-time"},
+			},
+			wantModified: updateTime,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenSecret := &kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{
+					CreationTimestamp: kmetav1.NewTime(creationTime),
+					ManagedFields:     tc.givenFields,
+				},
+			}
+
+			// when, then
+			require.Equal(t, tc.wantModified, getLastModifiedTime(givenSecret))
+		})
+	}
+}
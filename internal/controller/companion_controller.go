@@ -18,17 +18,31 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	kctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	"github.com/kyma-project/kyma-companion-manager/internal/backendmanager"
+	kcmmetrics "github.com/kyma-project/kyma-companion-manager/internal/metrics"
 	"github.com/kyma-project/kyma-companion-manager/pkg/env"
 	"github.com/kyma-project/kyma-companion-manager/pkg/equality"
 	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
@@ -37,6 +51,17 @@ import (
 const (
 	FinalizerName  = "companion.operator.kyma-project.io/finalizer"
 	ControllerName = "kyma-companion-manager"
+
+	dependencyNotFoundRequeueDelay = 30 * time.Second
+	deletionRequeueDelay           = 2 * time.Second
+	// deploymentNotAvailableRequeueDelay is the delay to check the rollout of the deployment again.
+	deploymentNotAvailableRequeueDelay = 10 * time.Second
+
+	// deploymentProgressDeadlineExceeded is the reason of the Progressing condition of a stalled deployment.
+	deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// secretRefsIndexKey is the field index of the Companion CRs by the secrets and configMaps they reference.
+	secretRefsIndexKey = "spec.secretRefs"
 )
 
 // Reconciler reconciles a Companion object.
@@ -45,7 +70,10 @@ type Reconciler struct {
 	Scheme         *runtime.Scheme
 	kubeClient     kcmk8s.Client
 	logger         *zap.SugaredLogger
+	recorder       record.EventRecorder
+	metrics        *kcmmetrics.Collector
 	backendManager backendmanager.Manager
+	driftDetector  equality.DriftDetector
 	config         env.Config
 }
 
@@ -55,26 +83,54 @@ func NewReconciler(
 	backendManager backendmanager.Manager,
 	scheme *runtime.Scheme,
 	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	metricsCollector *kcmmetrics.Collector,
 	config env.Config,
 ) *Reconciler {
 	return &Reconciler{
 		Client:         client,
 		Scheme:         scheme,
 		logger:         logger,
+		recorder:       recorder,
+		metrics:        metricsCollector,
 		backendManager: backendManager,
+		driftDetector:  newDriftDetector(config, kubeClient),
 		config:         config,
 		kubeClient:     kubeClient,
 	}
 }
 
+// newDriftDetector returns the DriftDetector selected in the given config.
+func newDriftDetector(config env.Config, kubeClient kcmk8s.Client) equality.DriftDetector {
+	if config.DriftDetection == env.DriftDetectionDryRun {
+		return equality.NewDryRunDriftDetector(kubeClient, kcmk8s.FieldManager)
+	}
+	return equality.NewSemanticDriftDetector()
+}
+
 // RBAC permissions.
 //nolint:lll // ignore long line length due to kubebuilder markers.
 // +kubebuilder:rbac:groups=operator.kyma-project.io,resources=companions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.kyma-project.io,resources=companions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.kyma-project.io,resources=companions/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="autoscaling",resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
+// The escalate and bind verbs are required to grant the configured read access to the companion backend,
+// so they are limited to its ClusterRole.
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,resourceNames=kyma-companion-backend,verbs=escalate;bind
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="gateway.kyma-project.io",resources=apirules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.istio.io",resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="security.istio.io",resources=requestauthentications;authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -87,6 +143,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Re
 	// fetch latest CR.
 	currentCompanion := &kcmv1alpha1.Companion{}
 	if err := r.Get(ctx, req.NamespacedName, currentCompanion); err != nil {
+		if kapierrors.IsNotFound(err) {
+			r.metrics.RemoveCompanion(req.Namespace, req.Name)
+		}
 		return kctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -98,7 +157,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Re
 
 	// check if companion CR is in deletion state.
 	if !companionCR.DeletionTimestamp.IsZero() {
-		return r.handleCompanionDeletion(ctx, companionCR, log)
+		result, err := r.handleCompanionDeletion(ctx, companionCR, log)
+		r.metrics.RecordReconcileOutcome(kcmmetrics.PhaseDeletion, err)
+		return result, err
 	}
 
 	// handle reconciliation.
@@ -110,27 +171,77 @@ func (r *Reconciler) handleCompanionReconcile(ctx context.Context,
 ) (kctrl.Result, error) {
 	log.Info("handling Companion reconciliation...")
 
+	// only the active Companion CR is reconciled, as the backend resources can only exist once in the cluster.
+	isActive, err := r.checkActiveCompanion(ctx, companion, log)
+	if err != nil {
+		return kctrl.Result{}, err
+	}
+	if !isActive {
+		return kctrl.Result{}, r.syncCompanionStatus(ctx, companion, log)
+	}
+
 	// make sure the finalizer exists.
 	if !r.containsFinalizer(companion) {
 		return r.addFinalizer(ctx, companion)
 	}
 
+	// validate the spec of the Companion CR, as a misconfiguration cannot be fixed by retrying.
+	if !r.validateCompanionSpec(companion, log) {
+		return kctrl.Result{}, r.syncCompanionStatus(ctx, companion, log)
+	}
+
+	// keep the manual changes of the managed resources while the reconciliation is paused.
+	if paused, until := companion.GetReconcilePause(time.Now()); paused {
+		return r.handlePausedReconcile(ctx, companion, until, log)
+	}
+	r.resumeReconcile(companion, log)
+
 	//	reconcile secret of kyma-companion-backend.
 	log.Info("reconciling secret...")
-	err := r.reconcileSecret(ctx, companion, log)
+	secretSyncStart := time.Now()
+	backendSecret, err := r.reconcileSecret(ctx, companion, log)
+	r.metrics.ObserveSecretSyncDuration(time.Since(secretSyncStart))
+	r.metrics.RecordReconcileOutcome(kcmmetrics.PhaseSecret, err)
 	if err != nil {
-		return kctrl.Result{}, err
+		if errors.Is(err, backendmanager.ErrDependencyNotFound) {
+			log.Warnw("referenced backend dependency is missing", "error", err)
+			return kctrl.Result{RequeueAfter: dependencyNotFoundRequeueDelay},
+				r.syncCompanionStatus(ctx, companion, log)
+		}
+		return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
+	}
+
+	//	reconcile the access of kyma-companion-backend to the cluster.
+	log.Info("reconciling backend access...")
+	err = r.reconcileBackendAccess(ctx, companion, log)
+	if err != nil {
+		return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
 	}
 
 	//	reconcile deployment of kyma-companion-backend.
 	log.Info("reconciling deployment...")
-	err = r.reconcileDeployment(ctx, companion, log)
+	err = r.reconcileDeployment(ctx, companion, backendSecret, log)
+	r.metrics.RecordReconcileOutcome(kcmmetrics.PhaseDeployment, err)
 	if err != nil {
-		return kctrl.Result{}, err
+		return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
+	}
+
+	//	reconcile the resources around the deployment of kyma-companion-backend.
+	log.Info("reconciling backend resources...")
+	err = r.reconcileBackendResources(ctx, companion, backendSecret, log)
+	if err != nil {
+		return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
 	}
 
 	log.Info("companion reconciliation completed!")
-	return kctrl.Result{}, nil
+
+	// requeue until the backend is available, as the pods of the deployment are not watched.
+	if !meta.IsStatusConditionTrue(companion.Status.Conditions, kcmv1alpha1.ConditionTypeDeploymentAvailable) {
+		log.Info("waiting for the deployment of the companion backend to become available...")
+		return kctrl.Result{RequeueAfter: deploymentNotAvailableRequeueDelay},
+			r.syncCompanionStatus(ctx, companion, log)
+	}
+	return kctrl.Result{}, r.syncCompanionStatus(ctx, companion, log)
 }
 
 func (r *Reconciler) handleCompanionDeletion(ctx context.Context, companion *kcmv1alpha1.Companion,
@@ -143,15 +254,120 @@ func (r *Reconciler) handleCompanionDeletion(ctx context.Context, companion *kcm
 	}
 
 	log.Info("handling Companion deletion...")
+	// the managed resources belong to the active Companion CR, so they must be kept for the other ones.
+	activeCompanion, err := r.getActiveCompanion(ctx, companion)
+	if err != nil {
+		return kctrl.Result{}, err
+	}
+	if !isSameCompanion(activeCompanion, companion) {
+		log.Info("skipped the deletion of the managed resources as the Companion CR is not the active one.")
+		return r.removeFinalizer(ctx, companion)
+	}
+
+	remainingResources, err := r.deleteManagedResources(ctx, companion, log)
+	if err != nil {
+		companion.SetStateDeleting(fmt.Sprintf("failed to delete the managed resources: %s", err))
+		return kctrl.Result{}, errors.Join(err, r.updateCompanionStatus(ctx, companion, log))
+	}
+
+	// keep the finalizer until all the managed resources are gone.
+	if len(remainingResources) > 0 {
+		log.Infow("waiting for the deletion of the managed resources", "resources", remainingResources)
+		companion.SetStateDeleting(fmt.Sprintf("Waiting for the deletion of: %s.",
+			strings.Join(remainingResources, ", ")))
+		return kctrl.Result{RequeueAfter: deletionRequeueDelay}, r.updateCompanionStatus(ctx, companion, log)
+	}
+
+	log.Info("all managed resources are deleted.")
+	r.metrics.RemoveCredentials()
+	r.recorder.Event(companion, kcorev1.EventTypeNormal, EventReasonDeletionCompleted,
+		"All the managed resources of the companion backend are deleted.")
 	return r.removeFinalizer(ctx, companion)
 }
 
+// deleteManagedResources deletes all resources which are managed for the given Companion CR.
+// It returns the resources which still exist after the deletion was requested.
+func (r *Reconciler) deleteManagedResources(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) ([]string, error) {
+	var remainingResources []string
+	for _, resource := range r.backendManager.GetManagedResources(companion) {
+		resourceName := fmt.Sprintf("%s %s/%s", resource.GetObjectKind().GroupVersionKind().Kind,
+			resource.GetNamespace(), resource.GetName())
+
+		exists, err := r.kubeClient.ResourceExists(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		log.Infof("deleting %s...", resourceName)
+		if err = r.kubeClient.DeleteResource(ctx, resource); err != nil {
+			return nil, err
+		}
+		remainingResources = append(remainingResources, resourceName)
+	}
+
+	remainingExposureResources, err := r.deleteExposureResources(ctx, companion, log)
+	if err != nil {
+		return nil, err
+	}
+	return append(remainingResources, remainingExposureResources...), nil
+}
+
+// deleteExposureResources deletes the resources which expose the companion backend outside of the cluster.
+// The resources whose CustomResourceDefinition is not installed are skipped. It returns the resources which
+// still exist after the deletion was requested.
+func (r *Reconciler) deleteExposureResources(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) ([]string, error) {
+	var remainingResources []string
+	for _, resource := range backendmanager.ExposureResources {
+		existing, err := r.kubeClient.GetUnstructured(ctx, resource, backendmanager.BackendResourceName,
+			companion.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			continue
+		}
+
+		resourceName := fmt.Sprintf("%s %s/%s", existing.GetKind(), existing.GetNamespace(), existing.GetName())
+		log.Infof("deleting %s...", resourceName)
+		if err = r.kubeClient.DeleteUnstructured(ctx, resource, existing.GetName(),
+			existing.GetNamespace()); err != nil {
+			return nil, err
+		}
+		remainingResources = append(remainingResources, resourceName)
+	}
+	return remainingResources, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr kctrl.Manager) error {
+	// index the Companion CRs by their referenced secrets and configMaps, to find them when those change.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &kcmv1alpha1.Companion{},
+		secretRefsIndexKey, secretRefsIndexer); err != nil {
+		return err
+	}
+
 	return kctrl.NewControllerManagedBy(mgr).
 		For(&kcmv1alpha1.Companion{}).
-		Owns(&kappsv1.Deployment{}). // watch for Deployments.
-		Owns(&kcorev1.Secret{}).     // watch for Secrets.
+		Owns(&kappsv1.Deployment{}).                     // watch for Deployments.
+		Owns(&kcorev1.Secret{}).                         // watch for Secrets.
+		Owns(&kcorev1.Service{}).                        // watch for Services.
+		Owns(&kautoscalingv2.HorizontalPodAutoscaler{}). // watch for HorizontalPodAutoscalers.
+		Owns(&kpolicyv1.PodDisruptionBudget{}).          // watch for PodDisruptionBudgets.
+		Owns(&knetworkingv1.NetworkPolicy{}).            // watch for NetworkPolicies.
+		Owns(&kcorev1.ServiceAccount{}).                 // watch for ServiceAccounts.
+		// watch for the secrets and configMaps referenced in the Companion CRs.
+		Watches(&kcorev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapReferencedObjectToCompanions)).
+		Watches(&kcorev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapReferencedObjectToCompanions)).
+		// elect a new active Companion CR when a Companion CR is deleted.
+		Watches(&kcmv1alpha1.Companion{}, handler.EnqueueRequestsFromMapFunc(r.mapCompanionToOtherCompanions),
+			builder.WithPredicates(companionDeletedPredicate())).
 		Complete(r)
 }
 
@@ -166,12 +382,17 @@ func (r *Reconciler) loggerWithCompanion(companion *kcmv1alpha1.Companion) *zap.
 	)
 }
 
+// reconcileDeployment reconciles the deployment of the companion backend. The pods of the deployment are
+// restarted if the data of the given backend secret changes.
 func (r *Reconciler) reconcileDeployment(ctx context.Context, companion *kcmv1alpha1.Companion,
-	log *zap.SugaredLogger,
+	backendSecret *kcorev1.Secret, log *zap.SugaredLogger,
 ) error {
-	// define deployment object.
-	expectedDeployment, err := r.backendManager.GenerateNewDeployment(companion, r.config.KymaCompanionBackendImage)
+	// define deployment object, with the image from the Companion CR taking precedence over the default one.
+	backendImage := backendmanager.ResolveImage(r.config.KymaCompanionBackendImage, companion.Spec.Companion.Image)
+	expectedDeployment, err := r.backendManager.GenerateNewDeployment(companion, backendImage, backendSecret)
 	if err != nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
 		return err
 	}
 
@@ -179,49 +400,513 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, companion *kcmv1al
 	existingDeployment, err := r.kubeClient.GetDeployment(ctx, expectedDeployment.GetName(),
 		expectedDeployment.GetNamespace())
 	if err != nil && !kapierrors.IsNotFound(err) {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
 		return err
 	}
 
 	// compare if the deployment needs to be updated.
-	if equality.Semantic.DeepEqual(existingDeployment, expectedDeployment) {
+	diffs, err := r.driftDetector.Diff(ctx, existingDeployment, expectedDeployment)
+	if err != nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
+		return err
+	}
+	applied := false
+	if len(diffs) == 0 {
 		log.Infof("deployment %s/%s already exists with expected configurations.",
 			expectedDeployment.Namespace, expectedDeployment.Name)
+	} else {
+		log.Infof("updating deployment %s/%s...", expectedDeployment.Namespace, expectedDeployment.Name)
+		if existingDeployment != nil {
+			r.recordDrift(kcmmetrics.KindDeployment, diffs, log)
+		}
+		if err = r.kubeClient.PatchApply(ctx, expectedDeployment); err != nil {
+			companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+				kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
+			return err
+		}
+		applied = true
+		r.recorder.Eventf(companion, kcorev1.EventTypeNormal, EventReasonDeploymentApplied,
+			"Applied the Deployment %s/%s.", expectedDeployment.Namespace, expectedDeployment.Name)
+	}
+
+	companion.Status.Image = backendImage
+
+	if existingDeployment != nil {
+		r.metrics.SetReadyReplicas(companion, existingDeployment.Status.ReadyReplicas)
+	}
+
+	// reflect the rollout status of the deployment.
+	if existingDeployment == nil || applied {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			"Waiting for the rollout of the deployment of the companion backend.")
+		return nil
+	}
+	return r.reflectDeploymentStatus(ctx, companion, existingDeployment)
+}
+
+// reflectDeploymentStatus sets the DeploymentAvailable condition from the status of the given deployment.
+// A deployment which is not available is reported as failed, if its pods fail or its rollout is stalled.
+// Otherwise, it is reported as still in progress.
+func (r *Reconciler) reflectDeploymentStatus(ctx context.Context, companion *kcmv1alpha1.Companion,
+	deployment *kappsv1.Deployment,
+) error {
+	if isDeploymentAvailable(deployment) {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionTrue,
+			kcmv1alpha1.ConditionReasonDeploymentAvailable, "Deployment of the companion backend is available.")
 		return nil
 	}
 
-	log.Infof("updating deployment %s/%s...", expectedDeployment.Namespace, expectedDeployment.Name)
-	return r.kubeClient.PatchApply(ctx, expectedDeployment)
+	// report a rollout which does not make any progress.
+	rolloutStalled := isDeploymentRolloutStalled(deployment)
+	if rolloutStalled {
+		r.recorder.Eventf(companion, kcorev1.EventTypeWarning, EventReasonDeploymentRolloutStalled,
+			"The rollout of the Deployment %s/%s exceeded its progress deadline.",
+			deployment.Namespace, deployment.Name)
+	}
+
+	// the pods tell best why the deployment is not available.
+	pods, err := r.kubeClient.ListPods(ctx, deployment.Namespace, deployment.Spec.Selector)
+	if err != nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
+		return err
+	}
+	if failureReason := getPodFailureReason(pods); failureReason != "" {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonBackendPodsFailed,
+			fmt.Sprintf("Pods of the companion backend are failing: %s.", failureReason))
+		return nil
+	}
+
+	if rolloutStalled {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentRolloutFailed,
+			"The rollout of the deployment of the companion backend exceeded its progress deadline.")
+		return nil
+	}
+
+	companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+		kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+		fmt.Sprintf("Waiting for the deployment of the companion backend to become available: "+
+			"%d of %d updated replicas are available.",
+			deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas))
+	return nil
 }
 
+// reconcileSecret reconciles the secret of the companion backend and returns the expected secret.
 func (r *Reconciler) reconcileSecret(ctx context.Context, companion *kcmv1alpha1.Companion,
 	log *zap.SugaredLogger,
-) error {
+) (*kcorev1.Secret, error) {
 	// get backend config.
-	backendConfig, err := r.backendManager.GetBackendConfig(ctx)
+	backendConfig, err := r.backendManager.GetBackendConfig(ctx, companion)
 	if err != nil {
-		return err
+		reason := kcmv1alpha1.ConditionReasonSecretsResolveFailed
+		switch {
+		case errors.Is(err, backendmanager.ErrDependencyNotFound):
+			reason = kcmv1alpha1.ConditionReasonSecretNotFound
+			r.recorder.Event(companion, kcorev1.EventTypeWarning, EventReasonSecretNotFound, err.Error())
+		case errors.Is(err, backendmanager.ErrInvalidDependency):
+			// the error only names the missing or invalid keys, never their values.
+			reason = kcmv1alpha1.ConditionReasonSecretInvalid
+			r.recorder.Event(companion, kcorev1.EventTypeWarning, EventReasonSecretInvalid, err.Error())
+		}
+		companion.SetCondition(kcmv1alpha1.ConditionTypeSecretsResolved, kmetav1.ConditionFalse, reason, err.Error())
+		return nil, err
+	}
+	companion.SetCondition(kcmv1alpha1.ConditionTypeSecretsResolved, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonSecretsResolved, "All referenced secrets and configmaps are resolved.")
+	for secretSpec, lastModified := range backendConfig.CredentialsLastModified {
+		r.metrics.SetCredentialsLastModified(secretSpec.Namespace, secretSpec.Name, lastModified)
 	}
 
 	// define secret.
 	expectedSecret, err := r.backendManager.GenerateNewSecret(companion, *backendConfig)
 	if err != nil {
-		return err
+		companion.SetCondition(kcmv1alpha1.ConditionTypeBackendSecretSynced, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonBackendSecretSyncFailed, err.Error())
+		return nil, err
 	}
 
 	// fetch existing secret.
 	existingSecret, err := r.kubeClient.GetSecret(ctx, expectedSecret.GetName(),
 		expectedSecret.GetNamespace())
 	if err != nil && !kapierrors.IsNotFound(err) {
-		return err
+		companion.SetCondition(kcmv1alpha1.ConditionTypeBackendSecretSynced, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonBackendSecretSyncFailed, err.Error())
+		return nil, err
 	}
 
 	// compare if the secret needs to be updated.
-	if equality.Semantic.DeepEqual(existingSecret, expectedSecret) {
+	diffs, err := r.driftDetector.Diff(ctx, existingSecret, expectedSecret)
+	if err != nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeBackendSecretSynced, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonBackendSecretSyncFailed, err.Error())
+		return nil, err
+	}
+	if len(diffs) == 0 {
 		log.Infof("secret %s/%s already exists with expected data.",
 			expectedSecret.Namespace, expectedSecret.Name)
+	} else {
+		log.Infof("updating secret %s/%s...", expectedSecret.Namespace, expectedSecret.Name)
+		if existingSecret != nil {
+			r.recordDrift(kcmmetrics.KindSecret, diffs, log)
+		}
+		if err = r.kubeClient.PatchApply(ctx, expectedSecret); err != nil {
+			companion.SetCondition(kcmv1alpha1.ConditionTypeBackendSecretSynced, kmetav1.ConditionFalse,
+				kcmv1alpha1.ConditionReasonBackendSecretSyncFailed, err.Error())
+			return nil, err
+		}
+		if existingSecret == nil {
+			r.recorder.Eventf(companion, kcorev1.EventTypeNormal, EventReasonBackendSecretCreated,
+				"Created the Secret %s/%s.", expectedSecret.Namespace, expectedSecret.Name)
+		} else {
+			r.recorder.Eventf(companion, kcorev1.EventTypeNormal, EventReasonBackendSecretUpdated,
+				"Updated the Secret %s/%s.", expectedSecret.Namespace, expectedSecret.Name)
+		}
+	}
+
+	companion.SetCondition(kcmv1alpha1.ConditionTypeBackendSecretSynced, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonBackendSecretSynced, "Secret of the companion backend is synced.")
+	return expectedSecret, nil
+}
+
+// checkActiveCompanion checks if the given Companion CR is the active one in the cluster
+// and sets the Active condition accordingly.
+func (r *Reconciler) checkActiveCompanion(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) (bool, error) {
+	activeCompanion, err := r.getActiveCompanion(ctx, companion)
+	if err != nil {
+		return false, err
+	}
+
+	if !isSameCompanion(activeCompanion, companion) {
+		activeKey := client.ObjectKeyFromObject(activeCompanion).String()
+		log.Warnw("ignoring Companion CR as another one is active", "activeCompanion", activeKey)
+		companion.SetCondition(kcmv1alpha1.ConditionTypeActive, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDuplicateCompanion,
+			fmt.Sprintf("Only a single Companion CR is allowed in the cluster. "+
+				"This Companion CR is ignored, because %s is the active one.", activeKey))
+		return false, nil
+	}
+
+	companion.SetCondition(kcmv1alpha1.ConditionTypeActive, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonActive, "Companion CR is the active one in the cluster.")
+	return true, nil
+}
+
+// validateCompanionSpec validates the spec and the annotations of the Companion CR and sets the SpecValid
+// condition accordingly. It returns false if the spec or the annotations are invalid.
+func (r *Reconciler) validateCompanionSpec(companion *kcmv1alpha1.Companion, log *zap.SugaredLogger) bool {
+	if errs := append(companion.ValidateSpec(), companion.ValidateAnnotations()...); len(errs) > 0 {
+		log.Warnw("invalid Companion spec", "error", errs.ToAggregate())
+		companion.SetCondition(kcmv1alpha1.ConditionTypeSpecValid, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonSpecInvalid, errs.ToAggregate().Error())
+		return false
+	}
+	companion.SetCondition(kcmv1alpha1.ConditionTypeSpecValid, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonSpecValid, "Spec of the Companion CR is valid.")
+	return true
+}
+
+// handlePausedReconcile sets the Paused condition and reflects the status of the deployment of the companion
+// backend, without changing any of the managed resources. If the pause has an expiry, the Companion CR is
+// requeued when it expires.
+func (r *Reconciler) handlePausedReconcile(ctx context.Context, companion *kcmv1alpha1.Companion,
+	until *time.Time, log *zap.SugaredLogger,
+) (kctrl.Result, error) {
+	message := fmt.Sprintf("Reconciliation is paused by the annotation %s.", kcmv1alpha1.ReconcileAnnotation)
+	if until != nil {
+		message = fmt.Sprintf("Reconciliation is paused by the annotation %s until %s.",
+			kcmv1alpha1.ReconcileAnnotation, until.UTC().Format(time.RFC3339))
+	}
+	if !meta.IsStatusConditionTrue(companion.Status.Conditions, kcmv1alpha1.ConditionTypePaused) {
+		r.recorder.Event(companion, kcorev1.EventTypeWarning, EventReasonReconcilePaused,
+			message+" The managed resources are not changed.")
+	}
+	companion.SetCondition(kcmv1alpha1.ConditionTypePaused, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonReconcilePaused, message)
+	log.Infow("skipped the reconciliation of the managed resources as it is paused", "until", until)
+
+	// the status of the deployment is still reflected, to follow the effect of the manual changes.
+	deployment, err := r.kubeClient.GetDeployment(ctx, backendmanager.BackendResourceName, companion.GetNamespace())
+	if err != nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentSyncFailed, err.Error())
+		return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
+	}
+	if deployment == nil {
+		companion.SetCondition(kcmv1alpha1.ConditionTypeDeploymentAvailable, kmetav1.ConditionFalse,
+			kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			"Deployment of the companion backend does not exist and is not created while the reconciliation "+
+				"is paused.")
+	} else {
+		r.metrics.SetReadyReplicas(companion, deployment.Status.ReadyReplicas)
+		if err = r.reflectDeploymentStatus(ctx, companion, deployment); err != nil {
+			return kctrl.Result{}, errors.Join(err, r.syncCompanionStatus(ctx, companion, log))
+		}
+	}
+
+	// requeue until the backend is available, as the pods of the deployment are not watched.
+	var result kctrl.Result
+	if !meta.IsStatusConditionTrue(companion.Status.Conditions, kcmv1alpha1.ConditionTypeDeploymentAvailable) {
+		result.RequeueAfter = deploymentNotAvailableRequeueDelay
+	}
+	if until != nil && (result.RequeueAfter == 0 || time.Until(*until) < result.RequeueAfter) {
+		result.RequeueAfter = time.Until(*until)
+	}
+	return result, r.syncCompanionStatus(ctx, companion, log)
+}
+
+// resumeReconcile removes the Paused condition, if the reconciliation was paused before.
+func (r *Reconciler) resumeReconcile(companion *kcmv1alpha1.Companion, log *zap.SugaredLogger) {
+	if meta.FindStatusCondition(companion.Status.Conditions, kcmv1alpha1.ConditionTypePaused) == nil {
+		return
+	}
+	log.Info("resuming the reconciliation of the managed resources")
+	r.recorder.Event(companion, kcorev1.EventTypeNormal, EventReasonReconcileResumed,
+		"Reconciliation is resumed. Manual changes of the managed resources are reverted.")
+	meta.RemoveStatusCondition(&companion.Status.Conditions, kcmv1alpha1.ConditionTypePaused)
+}
+
+// backendResourceReconciler reconciles a single kind of the resources which support the companion backend.
+type backendResourceReconciler struct {
+	kind      string
+	reconcile func(ctx context.Context, companion *kcmv1alpha1.Companion, log *zap.SugaredLogger) error
+}
+
+// reconcileBackendAccess reconciles the ServiceAccount of the companion backend and its read access to the
+// cluster. It must run before the deployment is reconciled, as the pods reference the ServiceAccount.
+func (r *Reconciler) reconcileBackendAccess(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return r.reconcileResources(ctx, companion, []backendResourceReconciler{
+		{kind: "ServiceAccount", reconcile: r.reconcileServiceAccount},
+		{kind: "ClusterRole", reconcile: r.reconcileClusterRole},
+		{kind: "ClusterRoleBinding", reconcile: r.reconcileClusterRoleBinding},
+	}, log)
+}
+
+// reconcileBackendResources reconciles the resources which support the deployment of the companion backend,
+// e.g. the Service, the HorizontalPodAutoscaler, the PodDisruptionBudget, the NetworkPolicy and the exposure.
+func (r *Reconciler) reconcileBackendResources(ctx context.Context, companion *kcmv1alpha1.Companion,
+	backendSecret *kcorev1.Secret, log *zap.SugaredLogger,
+) error {
+	return r.reconcileResources(ctx, companion, []backendResourceReconciler{
+		{kind: "Service", reconcile: r.reconcileService},
+		{kind: "HorizontalPodAutoscaler", reconcile: r.reconcileHPA},
+		{kind: "PodDisruptionBudget", reconcile: r.reconcilePDB},
+		{kind: "NetworkPolicy", reconcile: func(ctx context.Context, companion *kcmv1alpha1.Companion,
+			log *zap.SugaredLogger,
+		) error {
+			return r.reconcileNetworkPolicy(ctx, companion, backendSecret, log)
+		}},
+		{kind: "Exposure", reconcile: r.reconcileExposure},
+	}, log)
+}
+
+// reconcileResources runs the given reconcilers in order and sets the BackendResourcesSynced condition.
+// It stops at the first reconciler which fails.
+func (r *Reconciler) reconcileResources(ctx context.Context, companion *kcmv1alpha1.Companion,
+	reconcilers []backendResourceReconciler, log *zap.SugaredLogger,
+) error {
+	for _, reconciler := range reconcilers {
+		if err := reconciler.reconcile(ctx, companion, log); err != nil {
+			companion.SetCondition(kcmv1alpha1.ConditionTypeBackendResourcesSynced, kmetav1.ConditionFalse,
+				kcmv1alpha1.ConditionReasonBackendResourcesSyncFailed,
+				fmt.Sprintf("failed to sync %s: %s", reconciler.kind, err))
+			return err
+		}
+	}
+
+	companion.SetCondition(kcmv1alpha1.ConditionTypeBackendResourcesSynced, kmetav1.ConditionTrue,
+		kcmv1alpha1.ConditionReasonBackendResourcesSynced, "Resources of the companion backend are synced.")
+	return nil
+}
+
+// reconcileResource creates, updates or deletes a resource of the companion backend. The resource is deleted if
+// generate returns nil. The existing resource is fetched by the name of the backend resources in the given
+// namespace, which is empty for cluster-scoped resources.
+func reconcileResource[T interface {
+	comparable
+	client.Object
+}](ctx context.Context, r *Reconciler, kind, namespace string,
+	generate func() (T, error),
+	get func(ctx context.Context, name, namespace string) (T, error),
+	log *zap.SugaredLogger,
+) error {
+	var none T
+
+	// define expected object.
+	expected, err := generate()
+	if err != nil {
+		return err
+	}
+
+	// fetch existing object.
+	existing, err := get(ctx, backendmanager.BackendResourceName, namespace)
+	if err != nil {
+		return err
+	}
+
+	// delete the object if it is not needed anymore.
+	if expected == none {
+		if existing == none {
+			return nil
+		}
+		log.Infof("deleting %s %s...", kind, objectName(existing))
+		return r.kubeClient.DeleteResource(ctx, existing)
+	}
+
+	// compare if the object needs to be updated.
+	diffs, err := r.driftDetector.Diff(ctx, existing, expected)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		log.Infof("%s %s already exists with expected configurations.", kind, objectName(expected))
 		return nil
 	}
 
-	log.Infof("updating secret %s/%s...", expectedSecret.Namespace, expectedSecret.Name)
-	return r.kubeClient.PatchApply(ctx, expectedSecret)
+	log.Infof("updating %s %s...", kind, objectName(expected))
+	return r.kubeClient.PatchApply(ctx, expected)
+}
+
+func (r *Reconciler) reconcileService(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "Service", companion.GetNamespace(),
+		func() (*kcorev1.Service, error) {
+			return r.backendManager.GenerateNewService(companion)
+		},
+		r.kubeClient.GetService, log)
+}
+
+func (r *Reconciler) reconcileHPA(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "HorizontalPodAutoscaler", companion.GetNamespace(),
+		func() (*kautoscalingv2.HorizontalPodAutoscaler, error) {
+			return r.backendManager.GenerateNewHPA(companion)
+		},
+		r.kubeClient.GetHorizontalPodAutoscaler, log)
+}
+
+// reconcilePDB creates or updates the PodDisruptionBudget of the companion backend.
+// It deletes the PodDisruptionBudget if it is no longer needed, i.e. if the max replicas are not greater than 1.
+func (r *Reconciler) reconcilePDB(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "PodDisruptionBudget", companion.GetNamespace(),
+		func() (*kpolicyv1.PodDisruptionBudget, error) {
+			return r.backendManager.GenerateNewPDB(companion)
+		},
+		r.kubeClient.GetPodDisruptionBudget, log)
+}
+
+// reconcileNetworkPolicy creates or updates the NetworkPolicy of the companion backend.
+// It deletes the NetworkPolicy if it is no longer enabled in the Companion CR.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, companion *kcmv1alpha1.Companion,
+	backendSecret *kcorev1.Secret, log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "NetworkPolicy", companion.GetNamespace(),
+		func() (*knetworkingv1.NetworkPolicy, error) {
+			return r.backendManager.GenerateNewNetworkPolicy(companion, backendSecret)
+		},
+		r.kubeClient.GetNetworkPolicy, log)
+}
+
+func (r *Reconciler) reconcileServiceAccount(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "ServiceAccount", companion.GetNamespace(),
+		func() (*kcorev1.ServiceAccount, error) {
+			return r.backendManager.GenerateNewServiceAccount(companion)
+		},
+		r.kubeClient.GetServiceAccount, log)
+}
+
+func (r *Reconciler) reconcileClusterRole(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "ClusterRole", "",
+		func() (*krbacv1.ClusterRole, error) {
+			return r.backendManager.GenerateNewClusterRole(companion)
+		},
+		func(ctx context.Context, name, _ string) (*krbacv1.ClusterRole, error) {
+			return r.kubeClient.GetClusterRole(ctx, name)
+		}, log)
+}
+
+func (r *Reconciler) reconcileClusterRoleBinding(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	return reconcileResource(ctx, r, "ClusterRoleBinding", "",
+		func() (*krbacv1.ClusterRoleBinding, error) {
+			return r.backendManager.GenerateNewClusterRoleBinding(companion)
+		},
+		func(ctx context.Context, name, _ string) (*krbacv1.ClusterRoleBinding, error) {
+			return r.kubeClient.GetClusterRoleBinding(ctx, name)
+		}, log)
+}
+
+// reconcileExposure reconciles the resources which expose the companion backend outside of the cluster, and sets
+// the public URL in the status. The resources which are not needed for the configured exposure are deleted.
+func (r *Reconciler) reconcileExposure(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	// skip fetching the exposure objects if the companion backend is not, and was not exposed.
+	if companion.Spec.Companion.Exposure == nil && companion.Status.PublicURL == "" {
+		return nil
+	}
+
+	// define exposure objects.
+	expectedResources, err := r.backendManager.GenerateNewExposure(companion)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range backendmanager.ExposureResources {
+		// fetch existing object.
+		existing, err := r.kubeClient.GetUnstructured(ctx, resource, backendmanager.BackendResourceName,
+			companion.GetNamespace())
+		if err != nil {
+			return err
+		}
+
+		// delete the object if it is not needed anymore.
+		expected, ok := expectedResources[resource]
+		if !ok {
+			if existing != nil {
+				log.Infof("deleting %s %s/%s...", existing.GetKind(), existing.GetNamespace(), existing.GetName())
+				if err = r.kubeClient.DeleteUnstructured(ctx, resource, existing.GetName(),
+					existing.GetNamespace()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		// compare if the object needs to be updated.
+		diffs, err := r.driftDetector.Diff(ctx, existing, expected)
+		if err != nil {
+			return err
+		}
+		if len(diffs) == 0 {
+			log.Infof("%s %s/%s already exists with expected configurations.",
+				expected.GetKind(), expected.GetNamespace(), expected.GetName())
+			continue
+		}
+
+		log.Infof("updating %s %s/%s...", expected.GetKind(), expected.GetNamespace(), expected.GetName())
+		if err = r.kubeClient.ApplyUnstructured(ctx, resource, expected); err != nil {
+			return err
+		}
+	}
+
+	companion.Status.PublicURL = backendmanager.GetPublicURL(companion)
+	return nil
 }
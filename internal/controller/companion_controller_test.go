@@ -5,18 +5,73 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	"github.com/kyma-project/kyma-companion-manager/internal/backendmanager"
+	"github.com/kyma-project/kyma-companion-manager/pkg/env"
+	"github.com/kyma-project/kyma-companion-manager/pkg/equality"
+	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
+	kcmk8sapirule "github.com/kyma-project/kyma-companion-manager/pkg/k8s/apirule"
+	kcmk8shpa "github.com/kyma-project/kyma-companion-manager/pkg/k8s/hpa"
+	kcmk8sistio "github.com/kyma-project/kyma-companion-manager/pkg/k8s/istio"
+	kcmk8snetworkpolicy "github.com/kyma-project/kyma-companion-manager/pkg/k8s/networkpolicy"
+	kcmk8spdb "github.com/kyma-project/kyma-companion-manager/pkg/k8s/pdb"
+	kcmk8srbac "github.com/kyma-project/kyma-companion-manager/pkg/k8s/rbac"
+	kcmk8sservice "github.com/kyma-project/kyma-companion-manager/pkg/k8s/service"
 	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
 )
 
+var errTest = errors.New("test error")
+
+// requireConditionReason asserts the reason of the given condition type. An empty reason asserts
+// that the condition is not set.
+func requireConditionReason(t *testing.T, companion *kcmv1alpha1.Companion, conditionType, wantReason string) {
+	t.Helper()
+	gotCondition := meta.FindStatusCondition(companion.Status.Conditions, conditionType)
+	if wantReason == "" {
+		require.Nil(t, gotCondition)
+		return
+	}
+	require.NotNil(t, gotCondition)
+	require.Equal(t, wantReason, gotCondition.Reason)
+}
+
+// requireEventReasons asserts the reasons of all the Events recorded so far.
+func requireEventReasons(t *testing.T, recorder *record.FakeRecorder, wantReasons []string) {
+	t.Helper()
+	gotReasons := []string{}
+	for len(recorder.Events) > 0 {
+		// the fake recorder formats the Events as "<type> <reason> <message>".
+		fields := strings.Fields(<-recorder.Events)
+		require.GreaterOrEqual(t, len(fields), 2)
+		gotReasons = append(gotReasons, fields[1])
+	}
+	require.ElementsMatch(t, wantReasons, gotReasons)
+}
+
 func Test_loggerWithCompanion(t *testing.T) {
 	t.Parallel()
 
@@ -31,6 +86,197 @@ func Test_loggerWithCompanion(t *testing.T) {
 	require.NotNil(t, gotLogger)
 }
 
+func Test_newDriftDetector(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name              string
+		givenConfig       env.Config
+		wantDriftDetector equality.DriftDetector
+	}{
+		{
+			name:              "should use the semantic drift detector by default",
+			wantDriftDetector: equality.NewSemanticDriftDetector(),
+		},
+		{
+			name:              "should use the semantic drift detector when it is configured",
+			givenConfig:       env.Config{DriftDetection: env.DriftDetectionSemantic},
+			wantDriftDetector: equality.NewSemanticDriftDetector(),
+		},
+		{
+			name:              "should use the dry-run drift detector when it is configured",
+			givenConfig:       env.Config{DriftDetection: env.DriftDetectionDryRun},
+			wantDriftDetector: equality.NewDryRunDriftDetector(nil, kcmk8s.FieldManager),
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotDriftDetector := newDriftDetector(tc.givenConfig, nil)
+
+			// then
+			require.Equal(t, tc.wantDriftDetector, gotDriftDetector)
+		})
+	}
+}
+
+func Test_checkActiveCompanion(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                string
+		givenOtherAge       time.Duration
+		wantActive          bool
+		wantConditionReason string
+	}{
+		{
+			name:                "should be active when it is the oldest Companion CR",
+			givenOtherAge:       -time.Hour,
+			wantActive:          true,
+			wantConditionReason: kcmv1alpha1.ConditionReasonActive,
+		},
+		{
+			name:                "should not be active when another Companion CR is older",
+			givenOtherAge:       time.Hour,
+			wantActive:          false,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDuplicateCompanion,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			now := time.Now()
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.CreationTimestamp = kmetav1.NewTime(now)
+			givenOtherCompanion := testutils.NewCompanionCR()
+			givenOtherCompanion.CreationTimestamp = kmetav1.NewTime(now.Add(-tc.givenOtherAge))
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion, givenOtherCompanion)
+
+			// when
+			gotActive, err := testEnv.Reconciler.checkActiveCompanion(context.Background(), givenCompanion,
+				testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantActive, gotActive)
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeActive, tc.wantConditionReason)
+			givenCompanion.UpdateStateFromConditions()
+			if !tc.wantActive {
+				require.Equal(t, kcmv1alpha1.StateWarning, givenCompanion.Status.State)
+			}
+		})
+	}
+}
+
+func Test_checkActiveCompanion_Takeover(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Now()
+	givenCompanion := testutils.NewCompanionCR()
+	givenCompanion.CreationTimestamp = kmetav1.NewTime(now)
+	givenActiveCompanion := testutils.NewCompanionCR(testutils.WithCompanionCRFinalizer(FinalizerName))
+	givenActiveCompanion.CreationTimestamp = kmetav1.NewTime(now.Add(-time.Hour))
+	testEnv := NewMockedUnitTestEnvironment(t, givenCompanion, givenActiveCompanion)
+	ctx := context.Background()
+
+	// when
+	require.NoError(t, testEnv.Client.Delete(ctx, givenActiveCompanion))
+	gotActiveWhileDeleting, err := testEnv.Reconciler.checkActiveCompanion(ctx, givenCompanion, testEnv.Logger)
+
+	// then
+	require.NoError(t, err)
+	require.False(t, gotActiveWhileDeleting)
+	requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeActive,
+		kcmv1alpha1.ConditionReasonDuplicateCompanion)
+
+	// when
+	deletingCompanion, err := testEnv.GetCompanion(givenActiveCompanion.GetName(), givenActiveCompanion.GetNamespace())
+	require.NoError(t, err)
+	_, err = testEnv.Reconciler.removeFinalizer(ctx, &deletingCompanion)
+	require.NoError(t, err)
+	gotActiveAfterDeletion, err := testEnv.Reconciler.checkActiveCompanion(ctx, givenCompanion, testEnv.Logger)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, gotActiveAfterDeletion)
+	requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeActive, kcmv1alpha1.ConditionReasonActive)
+}
+
+func Test_validateCompanionSpec(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                string
+		givenResources      kcorev1.ResourceRequirements
+		givenAnnotations    map[string]string
+		wantValid           bool
+		wantConditionReason string
+	}{
+		{
+			name: "should be valid when requests are lower than limits",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{kcorev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   kcorev1.ResourceList{kcorev1.ResourceCPU: resource.MustParse("4")},
+			},
+			wantValid:           true,
+			wantConditionReason: kcmv1alpha1.ConditionReasonSpecValid,
+		},
+		{
+			name: "should be invalid when requests are larger than limits",
+			givenResources: kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{kcorev1.ResourceMemory: resource.MustParse("8Gi")},
+				Limits:   kcorev1.ResourceList{kcorev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			wantValid:           false,
+			wantConditionReason: kcmv1alpha1.ConditionReasonSpecInvalid,
+		},
+		{
+			name:                "should be invalid when the reconcile annotation has an unsupported value",
+			givenAnnotations:    map[string]string{kcmv1alpha1.ReconcileAnnotation: "pause"},
+			wantValid:           false,
+			wantConditionReason: kcmv1alpha1.ConditionReasonSpecInvalid,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR(testutils.WithAnnotations(tc.givenAnnotations))
+			givenCompanion.Spec.Companion.Resources = tc.givenResources
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// when
+			gotValid := testEnv.Reconciler.validateCompanionSpec(givenCompanion, testEnv.Logger)
+
+			// then
+			require.Equal(t, tc.wantValid, gotValid)
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeSpecValid, tc.wantConditionReason)
+			givenCompanion.UpdateStateFromConditions()
+			if !tc.wantValid {
+				require.Equal(t, kcmv1alpha1.StateWarning, givenCompanion.Status.State)
+			}
+		})
+	}
+}
+
 func Test_reconcileDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -39,35 +285,83 @@ func Test_reconcileDeployment(t *testing.T) {
 		name                    string
 		givenCompanion          *kcmv1alpha1.Companion
 		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, deployment *kappsv1.Deployment)
+		wantError               error
+		wantConditionStatus     kmetav1.ConditionStatus
+		wantConditionReason     string
+		wantEventReasons        []string
+		wantImage               string
 	}{
 		{
 			name:           "should update the deployment when it does not exist",
 			givenCompanion: testutils.NewCompanionCR(),
 			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
 				testEnv.backendManager.On("GenerateNewDeployment",
-					mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
 				testEnv.kubeClient.On("GetDeployment",
 					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
 				testEnv.kubeClient.On("PatchApply",
 					mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			wantEventReasons:    []string{EventReasonDeploymentApplied},
 		},
 		{
 			name:           "should not update the deployment when it exists",
 			givenCompanion: testutils.NewCompanionCR(),
 			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
 				testEnv.backendManager.On("GenerateNewDeployment",
-					mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+				testEnv.kubeClient.On("ListPods", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+		},
+		{
+			name:           "should set the DeploymentAvailable condition when the deployment is available",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+
+				availableDeployment := givenDeployment.DeepCopy()
+				availableDeployment.Status = kappsv1.DeploymentStatus{
+					Replicas:          1,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 1,
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionTrue},
+					},
+				}
 				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(availableDeployment, nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentAvailable,
+		},
+		{
+			name:           "should return error when the deployment cannot be applied",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
 					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply",
+					mock.Anything, mock.Anything, mock.Anything).Return(errTest).Once()
 			},
+			wantError:           errTest,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentSyncFailed,
 		},
 		{
 			name:           "should update the deployment when the existing deployment is different from expected",
 			givenCompanion: testutils.NewCompanionCR(),
 			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
 				testEnv.backendManager.On("GenerateNewDeployment",
-					mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
 
 				changedDeployment := givenDeployment.DeepCopy()
 				changedDeployment.Spec.Template.Spec.Containers[0].Image = "changed-image"
@@ -76,6 +370,95 @@ func Test_reconcileDeployment(t *testing.T) {
 				testEnv.kubeClient.On("PatchApply",
 					mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			wantEventReasons:    []string{EventReasonDeploymentApplied},
+		},
+		{
+			name:           "should update the deployment when only the image pull policy is different from expected",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+
+				changedDeployment := givenDeployment.DeepCopy()
+				changedDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = kcorev1.PullIfNotPresent
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(changedDeployment, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, mock.MatchedBy(func(obj *kappsv1.Deployment) bool {
+					return obj.Spec.Template.Spec.Containers[0].ImagePullPolicy == kcorev1.PullAlways
+				})).Return(nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			wantEventReasons:    []string{EventReasonDeploymentApplied},
+		},
+		{
+			name:           "should emit a warning Event when the rollout of the deployment is stalled",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+
+				stalledDeployment := givenDeployment.DeepCopy()
+				stalledDeployment.Status.Conditions = []kappsv1.DeploymentCondition{
+					{
+						Type: kappsv1.DeploymentProgressing, Status: kcorev1.ConditionFalse,
+						Reason: deploymentProgressDeadlineExceeded,
+					},
+				}
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(stalledDeployment, nil).Once()
+				testEnv.kubeClient.On("ListPods", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentRolloutFailed,
+			wantEventReasons:    []string{EventReasonDeploymentRolloutStalled},
+		},
+		{
+			name:           "should set the BackendPodsFailed reason when a pod of the deployment is failing",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenDeployment, nil).Once()
+
+				failingPod := kcorev1.Pod{
+					Status: kcorev1.PodStatus{
+						ContainerStatuses: []kcorev1.ContainerStatus{
+							{
+								Name: "backend",
+								State: kcorev1.ContainerState{
+									Waiting: &kcorev1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+								},
+							},
+						},
+					},
+				}
+				testEnv.kubeClient.On("ListPods", mock.Anything, mock.Anything, mock.Anything).Return(
+					[]kcorev1.Pod{failingPod}, nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonBackendPodsFailed,
+		},
+		{
+			name: "should deploy the image from the Companion CR instead of the default one",
+			givenCompanion: testutils.NewCompanionCR(testutils.WithImage(&kcmv1alpha1.ImageConfig{
+				Tag: "2.0.0",
+			})),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenDeployment *kappsv1.Deployment) {
+				testEnv.backendManager.On("GenerateNewDeployment",
+					mock.Anything, "kyma-companion:2.0.0", mock.Anything).Return(givenDeployment, nil).Once()
+				testEnv.kubeClient.On("GetDeployment",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			wantEventReasons:    []string{EventReasonDeploymentApplied},
+			wantImage:           "kyma-companion:2.0.0",
 		},
 	}
 
@@ -89,15 +472,25 @@ func Test_reconcileDeployment(t *testing.T) {
 			givenDeployment := testutils.NewCompanionDeployment("test-deployment",
 				"test-namespace")
 			testEnv := NewMockedUnitTestEnvironment(t, tc.givenCompanion)
+			testEnv.Reconciler.config.KymaCompanionBackendImage = "kyma-companion:1.0.0"
 
 			// define mocks behaviour
 			tc.givenMocksBehaviourFunc(testEnv, givenDeployment)
 
 			// when
-			err := testEnv.Reconciler.reconcileDeployment(context.TODO(), tc.givenCompanion, testEnv.Logger)
+			err := testEnv.Reconciler.reconcileDeployment(context.TODO(), tc.givenCompanion, nil, testEnv.Logger)
 
 			// then
-			require.NoError(t, err)
+			require.ErrorIs(t, err, tc.wantError)
+			gotCondition := meta.FindStatusCondition(tc.givenCompanion.Status.Conditions,
+				kcmv1alpha1.ConditionTypeDeploymentAvailable)
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, tc.wantConditionReason, gotCondition.Reason)
+			requireEventReasons(t, testEnv.Recorder, tc.wantEventReasons)
+			if tc.wantImage != "" {
+				require.Equal(t, tc.wantImage, tc.givenCompanion.Status.Image)
+			}
 			testEnv.backendManager.AssertExpectations(t)
 			testEnv.kubeClient.AssertExpectations(t)
 		})
@@ -113,6 +506,10 @@ func Test_reconcileSecret(t *testing.T) {
 		givenCompanion          *kcmv1alpha1.Companion
 		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, givenSecret *kcorev1.Secret,
 			givenConfig *backendmanager.Config)
+		wantError                     error
+		wantSecretsResolvedReason     string
+		wantBackendSecretSyncedReason string
+		wantEventReasons              []string
 	}{
 		{
 			name:           "should update the secret when it does not exist",
@@ -129,6 +526,9 @@ func Test_reconcileSecret(t *testing.T) {
 				testEnv.kubeClient.On("PatchApply",
 					mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
+			wantSecretsResolvedReason:     kcmv1alpha1.ConditionReasonSecretsResolved,
+			wantBackendSecretSyncedReason: kcmv1alpha1.ConditionReasonBackendSecretSynced,
+			wantEventReasons:              []string{EventReasonBackendSecretCreated},
 		},
 		{
 			name:           "should not update the secret when it exists",
@@ -143,6 +543,8 @@ func Test_reconcileSecret(t *testing.T) {
 				testEnv.kubeClient.On("GetSecret",
 					mock.Anything, mock.Anything, mock.Anything).Return(givenSecret, nil).Once()
 			},
+			wantSecretsResolvedReason:     kcmv1alpha1.ConditionReasonSecretsResolved,
+			wantBackendSecretSyncedReason: kcmv1alpha1.ConditionReasonBackendSecretSynced,
 		},
 		{
 			name:           "should update the secret when the existing secret is different from expected",
@@ -164,6 +566,54 @@ func Test_reconcileSecret(t *testing.T) {
 				testEnv.kubeClient.On("PatchApply",
 					mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
+			wantSecretsResolvedReason:     kcmv1alpha1.ConditionReasonSecretsResolved,
+			wantBackendSecretSyncedReason: kcmv1alpha1.ConditionReasonBackendSecretSynced,
+			wantEventReasons:              []string{EventReasonBackendSecretUpdated},
+		},
+		{
+			name:           "should set the SecretNotFound reason when a referenced secret is missing",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, _ *kcorev1.Secret,
+				_ *backendmanager.Config,
+			) {
+				testEnv.backendManager.On("GetBackendConfig",
+					mock.Anything, mock.Anything).Return(nil, backendmanager.ErrDependencyNotFound).Once()
+			},
+			wantError:                 backendmanager.ErrDependencyNotFound,
+			wantSecretsResolvedReason: kcmv1alpha1.ConditionReasonSecretNotFound,
+			wantEventReasons:          []string{EventReasonSecretNotFound},
+		},
+		{
+			name:           "should set the SecretInvalid reason when a referenced secret has an invalid structure",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, _ *kcorev1.Secret,
+				_ *backendmanager.Config,
+			) {
+				testEnv.backendManager.On("GetBackendConfig",
+					mock.Anything, mock.Anything).Return(nil, backendmanager.ErrInvalidDependency).Once()
+			},
+			wantError:                 backendmanager.ErrInvalidDependency,
+			wantSecretsResolvedReason: kcmv1alpha1.ConditionReasonSecretInvalid,
+			wantEventReasons:          []string{EventReasonSecretInvalid},
+		},
+		{
+			name:           "should return error when the secret cannot be applied",
+			givenCompanion: testutils.NewCompanionCR(),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenSecret *kcorev1.Secret,
+				givenConfig *backendmanager.Config,
+			) {
+				testEnv.backendManager.On("GenerateNewSecret",
+					mock.Anything, mock.Anything).Return(givenSecret, nil).Once()
+				testEnv.backendManager.On("GetBackendConfig",
+					mock.Anything, mock.Anything).Return(givenConfig, nil).Once()
+				testEnv.kubeClient.On("GetSecret",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply",
+					mock.Anything, mock.Anything, mock.Anything).Return(errTest).Once()
+			},
+			wantError:                     errTest,
+			wantSecretsResolvedReason:     kcmv1alpha1.ConditionReasonSecretsResolved,
+			wantBackendSecretSyncedReason: kcmv1alpha1.ConditionReasonBackendSecretSyncFailed,
 		},
 	}
 
@@ -185,12 +635,1099 @@ func Test_reconcileSecret(t *testing.T) {
 			tc.givenMocksBehaviourFunc(testEnv, givenSecret, &backendmanager.Config{})
 
 			// when
-			err := testEnv.Reconciler.reconcileSecret(context.TODO(), tc.givenCompanion, testEnv.Logger)
+			gotSecret, err := testEnv.Reconciler.reconcileSecret(context.TODO(), tc.givenCompanion, testEnv.Logger)
 
 			// then
-			require.NoError(t, err)
+			require.ErrorIs(t, err, tc.wantError)
+			if tc.wantError == nil {
+				require.Equal(t, givenSecret, gotSecret)
+			} else {
+				require.Nil(t, gotSecret)
+			}
+			requireConditionReason(t, tc.givenCompanion, kcmv1alpha1.ConditionTypeSecretsResolved,
+				tc.wantSecretsResolvedReason)
+			requireConditionReason(t, tc.givenCompanion, kcmv1alpha1.ConditionTypeBackendSecretSynced,
+				tc.wantBackendSecretSyncedReason)
+			requireEventReasons(t, testEnv.Recorder, tc.wantEventReasons)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileService(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, givenService *kcorev1.Service)
+		wantError               error
+	}{
+		{
+			name: "should create the Service when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenService *kcorev1.Service,
+			) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(givenService, nil).Once()
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenService).Return(nil).Once()
+			},
+		},
+		{
+			name: "should not update the Service when it exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenService *kcorev1.Service,
+			) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(givenService, nil).Once()
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					givenService.DeepCopy(), nil).Once()
+			},
+		},
+		{
+			name: "should update the Service when it has drifted",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenService *kcorev1.Service,
+			) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(givenService, nil).Once()
+				changedService := givenService.DeepCopy()
+				changedService.Spec.Ports = nil
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					changedService, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenService).Return(nil).Once()
+			},
+		},
+		{
+			name: "should return error when the Service cannot be applied",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenService *kcorev1.Service,
+			) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(givenService, nil).Once()
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenService).Return(errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenService := kcmk8sservice.NewService("test-service", givenCompanion.Namespace,
+				kcmk8sservice.WithSelector(map[string]string{"app": "test"}),
+				kcmk8sservice.WithPort("http", 8000, "http"),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv, givenService)
+
+			// when
+			err := testEnv.Reconciler.reconcileService(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
 			testEnv.backendManager.AssertExpectations(t)
 			testEnv.kubeClient.AssertExpectations(t)
 		})
 	}
 }
+
+func Test_reconcileHPA(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, givenHPA *kautoscalingv2.HorizontalPodAutoscaler)
+		wantError               error
+	}{
+		{
+			name: "should create the HorizontalPodAutoscaler when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenHPA *kautoscalingv2.HorizontalPodAutoscaler,
+			) {
+				testEnv.backendManager.On("GenerateNewHPA", mock.Anything).Return(givenHPA, nil).Once()
+				testEnv.kubeClient.On("GetHorizontalPodAutoscaler",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenHPA).Return(nil).Once()
+			},
+		},
+		{
+			name: "should not update the HorizontalPodAutoscaler when it exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenHPA *kautoscalingv2.HorizontalPodAutoscaler,
+			) {
+				testEnv.backendManager.On("GenerateNewHPA", mock.Anything).Return(givenHPA, nil).Once()
+				testEnv.kubeClient.On("GetHorizontalPodAutoscaler",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenHPA.DeepCopy(), nil).Once()
+			},
+		},
+		{
+			name: "should update the HorizontalPodAutoscaler when it has drifted",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenHPA *kautoscalingv2.HorizontalPodAutoscaler,
+			) {
+				testEnv.backendManager.On("GenerateNewHPA", mock.Anything).Return(givenHPA, nil).Once()
+				changedHPA := givenHPA.DeepCopy()
+				changedHPA.Spec.MaxReplicas = 10
+				testEnv.kubeClient.On("GetHorizontalPodAutoscaler",
+					mock.Anything, mock.Anything, mock.Anything).Return(changedHPA, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenHPA).Return(nil).Once()
+			},
+		},
+		{
+			name: "should return error when the HorizontalPodAutoscaler cannot be applied",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenHPA *kautoscalingv2.HorizontalPodAutoscaler,
+			) {
+				testEnv.backendManager.On("GenerateNewHPA", mock.Anything).Return(givenHPA, nil).Once()
+				testEnv.kubeClient.On("GetHorizontalPodAutoscaler",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenHPA).Return(errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenHPA := kcmk8shpa.NewHPA("test-hpa", givenCompanion.Namespace,
+				kcmk8shpa.WithScaleTargetDeployment("test-deployment"),
+				kcmk8shpa.WithMinReplicas(1),
+				kcmk8shpa.WithMaxReplicas(3),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv, givenHPA)
+
+			// when
+			err := testEnv.Reconciler.reconcileHPA(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcilePDB(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, givenPDB *kpolicyv1.PodDisruptionBudget)
+		wantError               error
+	}{
+		{
+			name: "should create the PodDisruptionBudget when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenPDB *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(givenPDB, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenPDB).Return(nil).Once()
+			},
+		},
+		{
+			name: "should not update the PodDisruptionBudget when it exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenPDB *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(givenPDB, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenPDB.DeepCopy(), nil).Once()
+			},
+		},
+		{
+			name: "should update the PodDisruptionBudget when it has drifted",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenPDB *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(givenPDB, nil).Once()
+				changedPDB := givenPDB.DeepCopy()
+				changedPDB.Spec.MaxUnavailable = ptr.To(intstr.FromInt32(3))
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(changedPDB, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenPDB).Return(nil).Once()
+			},
+		},
+		{
+			name: "should delete the PodDisruptionBudget when it is no longer needed",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenPDB *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenPDB, nil).Once()
+				testEnv.kubeClient.On("DeleteResource", mock.Anything, givenPDB).Return(nil).Once()
+			},
+		},
+		{
+			name: "should do nothing when the PodDisruptionBudget is neither needed nor exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				_ *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "should return error when the PodDisruptionBudget cannot be applied",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenPDB *kpolicyv1.PodDisruptionBudget,
+			) {
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(givenPDB, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenPDB).Return(errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenPDB := kcmk8spdb.NewPDB("test-pdb", givenCompanion.Namespace,
+				kcmk8spdb.WithSelector(map[string]string{"app": "test"}),
+				kcmk8spdb.WithMaxUnavailable(intstr.FromInt32(1)),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv, givenPDB)
+
+			// when
+			err := testEnv.Reconciler.reconcilePDB(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileNetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment,
+			givenNetworkPolicy *knetworkingv1.NetworkPolicy)
+		wantError error
+	}{
+		{
+			name: "should create the NetworkPolicy when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenNetworkPolicy *knetworkingv1.NetworkPolicy,
+			) {
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					givenNetworkPolicy, nil).Once()
+				testEnv.kubeClient.On("GetNetworkPolicy",
+					mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenNetworkPolicy).Return(nil).Once()
+			},
+		},
+		{
+			name: "should not update the NetworkPolicy when it exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenNetworkPolicy *knetworkingv1.NetworkPolicy,
+			) {
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					givenNetworkPolicy, nil).Once()
+				testEnv.kubeClient.On("GetNetworkPolicy",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenNetworkPolicy.DeepCopy(), nil).Once()
+			},
+		},
+		{
+			name: "should update the NetworkPolicy when it has drifted",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenNetworkPolicy *knetworkingv1.NetworkPolicy,
+			) {
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					givenNetworkPolicy, nil).Once()
+				changedNetworkPolicy := givenNetworkPolicy.DeepCopy()
+				changedNetworkPolicy.Spec.Egress = nil
+				testEnv.kubeClient.On("GetNetworkPolicy",
+					mock.Anything, mock.Anything, mock.Anything).Return(changedNetworkPolicy, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenNetworkPolicy).Return(nil).Once()
+			},
+		},
+		{
+			name: "should delete the NetworkPolicy when it is no longer enabled",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				givenNetworkPolicy *knetworkingv1.NetworkPolicy,
+			) {
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					nil, nil).Once()
+				testEnv.kubeClient.On("GetNetworkPolicy",
+					mock.Anything, mock.Anything, mock.Anything).Return(givenNetworkPolicy, nil).Once()
+				testEnv.kubeClient.On("DeleteResource", mock.Anything, givenNetworkPolicy).Return(nil).Once()
+			},
+		},
+		{
+			name: "should return error when the NetworkPolicy cannot be generated",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment,
+				_ *knetworkingv1.NetworkPolicy,
+			) {
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					nil, errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenNetworkPolicy := kcmk8snetworkpolicy.NewNetworkPolicy("test-network-policy", givenCompanion.Namespace,
+				kcmk8snetworkpolicy.WithPodSelector(map[string]string{"app": "test"}),
+				kcmk8snetworkpolicy.WithEgressRules(knetworkingv1.NetworkPolicyEgressRule{
+					To: []knetworkingv1.NetworkPolicyPeer{{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+				}),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv, givenNetworkPolicy)
+
+			// when
+			err := testEnv.Reconciler.reconcileNetworkPolicy(context.TODO(), givenCompanion,
+				&kcorev1.Secret{}, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileClusterRole(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment, givenClusterRole *krbacv1.ClusterRole)
+		wantError               error
+	}{
+		{
+			name: "should create the ClusterRole when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenClusterRole *krbacv1.ClusterRole) {
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(givenClusterRole, nil).Once()
+				testEnv.kubeClient.On("GetClusterRole", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenClusterRole).Return(nil).Once()
+			},
+		},
+		{
+			name: "should not update the ClusterRole when it exists",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenClusterRole *krbacv1.ClusterRole) {
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(givenClusterRole, nil).Once()
+				testEnv.kubeClient.On("GetClusterRole", mock.Anything, mock.Anything).Return(
+					givenClusterRole.DeepCopy(), nil).Once()
+			},
+		},
+		{
+			name: "should update the ClusterRole when its rules have drifted",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenClusterRole *krbacv1.ClusterRole) {
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(givenClusterRole, nil).Once()
+				changedClusterRole := givenClusterRole.DeepCopy()
+				changedClusterRole.Rules[0].Verbs = append(changedClusterRole.Rules[0].Verbs, "delete")
+				testEnv.kubeClient.On("GetClusterRole", mock.Anything, mock.Anything).Return(
+					changedClusterRole, nil).Once()
+				testEnv.kubeClient.On("PatchApply", mock.Anything, givenClusterRole).Return(nil).Once()
+			},
+		},
+		{
+			name: "should return error when the ClusterRole cannot be fetched",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment, givenClusterRole *krbacv1.ClusterRole) {
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(givenClusterRole, nil).Once()
+				testEnv.kubeClient.On("GetClusterRole", mock.Anything, mock.Anything).Return(nil, errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenClusterRole := kcmk8srbac.NewClusterRole("test-cluster-role",
+				kcmk8srbac.WithRule([]string{""}, []string{"pods"}, []string{"get", "list", "watch"}),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv, givenClusterRole)
+
+			// when
+			err := testEnv.Reconciler.reconcileClusterRole(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileBackendAccess(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment)
+		wantError               error
+		wantConditionReason     string
+		wantConditionMessage    string
+	}{
+		{
+			name: "should sync the ServiceAccount and its cluster access",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewServiceAccount", mock.Anything).Return(
+					&kcorev1.ServiceAccount{}, nil).Once()
+				testEnv.kubeClient.On("GetServiceAccount", mock.Anything, mock.Anything, mock.Anything).Return(
+					&kcorev1.ServiceAccount{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(
+					&krbacv1.ClusterRole{}, nil).Once()
+				testEnv.kubeClient.On("GetClusterRole", mock.Anything, mock.Anything).Return(
+					&krbacv1.ClusterRole{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewClusterRoleBinding", mock.Anything).Return(
+					&krbacv1.ClusterRoleBinding{}, nil).Once()
+				testEnv.kubeClient.On("GetClusterRoleBinding", mock.Anything, mock.Anything).Return(
+					&krbacv1.ClusterRoleBinding{}, nil).Once()
+			},
+			wantConditionReason: kcmv1alpha1.ConditionReasonBackendResourcesSynced,
+		},
+		{
+			name: "should stop and set the failed resource in the condition",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewServiceAccount", mock.Anything).Return(
+					&kcorev1.ServiceAccount{}, nil).Once()
+				testEnv.kubeClient.On("GetServiceAccount", mock.Anything, mock.Anything, mock.Anything).Return(
+					&kcorev1.ServiceAccount{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewClusterRole", mock.Anything).Return(nil, errTest).Once()
+			},
+			wantError:            errTest,
+			wantConditionReason:  kcmv1alpha1.ConditionReasonBackendResourcesSyncFailed,
+			wantConditionMessage: "failed to sync ClusterRole: test error",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv)
+
+			// when
+			err := testEnv.Reconciler.reconcileBackendAccess(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeBackendResourcesSynced,
+				tc.wantConditionReason)
+			if tc.wantConditionMessage != "" {
+				condition := meta.FindStatusCondition(givenCompanion.Status.Conditions,
+					kcmv1alpha1.ConditionTypeBackendResourcesSynced)
+				require.Equal(t, tc.wantConditionMessage, condition.Message)
+			}
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileExposure(t *testing.T) {
+	t.Parallel()
+
+	givenExposure := &kcmv1alpha1.ExposureConfig{
+		Type: kcmv1alpha1.ExposureTypeAPIRule,
+		Host: "companion.example.com",
+		AccessStrategy: kcmv1alpha1.AccessStrategy{
+			OAuth2: &kcmv1alpha1.OAuth2AccessStrategy{},
+		},
+	}
+	givenAPIRule := kcmk8sapirule.NewAPIRule(backendmanager.BackendResourceName, "test-namespace",
+		kcmk8sapirule.WithHost("companion.example.com"),
+	)
+	givenVirtualService := kcmk8sistio.NewVirtualService(backendmanager.BackendResourceName, "test-namespace",
+		kcmk8sistio.WithHosts("companion.example.com"),
+	)
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenExposure           *kcmv1alpha1.ExposureConfig
+		givenNotExposed         bool
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment)
+		wantError               error
+		wantPublicURL           string
+	}{
+		{
+			name:                    "should skip the exposure when the companion backend was never exposed",
+			givenExposure:           nil,
+			givenNotExposed:         true,
+			givenMocksBehaviourFunc: func(_ *MockedUnitTestEnvironment) {},
+		},
+		{
+			name:          "should create the APIRule and set the public URL",
+			givenExposure: givenExposure,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewExposure", mock.Anything).Return(
+					map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+						kcmk8sapirule.GroupVersionResource: givenAPIRule,
+					}, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources))
+				testEnv.kubeClient.On("ApplyUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					givenAPIRule).Return(nil).Once()
+			},
+			wantPublicURL: "https://companion.example.com",
+		},
+		{
+			name:          "should not update the APIRule when it exists",
+			givenExposure: givenExposure,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewExposure", mock.Anything).Return(
+					map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+						kcmk8sapirule.GroupVersionResource: givenAPIRule,
+					}, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(givenAPIRule.DeepCopy(), nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources) - 1)
+			},
+			wantPublicURL: "https://companion.example.com",
+		},
+		{
+			name:          "should delete the resources of another exposure type",
+			givenExposure: givenExposure,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewExposure", mock.Anything).Return(
+					map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+						kcmk8sapirule.GroupVersionResource: givenAPIRule,
+					}, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(givenAPIRule.DeepCopy(), nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sistio.VirtualServiceGVR,
+					mock.Anything, mock.Anything).Return(givenVirtualService, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources) - 2)
+				testEnv.kubeClient.On("DeleteUnstructured", mock.Anything, kcmk8sistio.VirtualServiceGVR,
+					givenVirtualService.GetName(), givenVirtualService.GetNamespace()).Return(nil).Once()
+			},
+			wantPublicURL: "https://companion.example.com",
+		},
+		{
+			name:          "should delete the APIRule and clear the public URL when the exposure is removed",
+			givenExposure: nil,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewExposure", mock.Anything).Return(
+					map[kschema.GroupVersionResource]*kunstructured.Unstructured{}, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(givenAPIRule, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources) - 1)
+				testEnv.kubeClient.On("DeleteUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					givenAPIRule.GetName(), givenAPIRule.GetNamespace()).Return(nil).Once()
+			},
+		},
+		{
+			name:          "should return error when the APIRule cannot be applied",
+			givenExposure: givenExposure,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewExposure", mock.Anything).Return(
+					map[kschema.GroupVersionResource]*kunstructured.Unstructured{
+						kcmk8sapirule.GroupVersionResource: givenAPIRule,
+					}, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(nil, nil).Once()
+				testEnv.kubeClient.On("ApplyUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					givenAPIRule).Return(errTest).Once()
+			},
+			wantError: errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			givenCompanion.Spec.Companion.Exposure = tc.givenExposure
+			if !tc.givenNotExposed {
+				givenCompanion.Status.PublicURL = "https://old.example.com"
+			}
+			if tc.wantError != nil {
+				tc.wantPublicURL = givenCompanion.Status.PublicURL
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv)
+
+			// when
+			err := testEnv.Reconciler.reconcileExposure(context.TODO(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			require.Equal(t, tc.wantPublicURL, givenCompanion.Status.PublicURL)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_reconcileBackendResources(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment)
+		wantError               error
+		wantConditionReason     string
+		wantConditionMessage    string
+	}{
+		{
+			name: "should sync all backend resources",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(&kcorev1.Service{}, nil).Once()
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					&kcorev1.Service{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewHPA", mock.Anything).Return(
+					&kautoscalingv2.HorizontalPodAutoscaler{}, nil).Once()
+				testEnv.kubeClient.On("GetHorizontalPodAutoscaler", mock.Anything, mock.Anything,
+					mock.Anything).Return(&kautoscalingv2.HorizontalPodAutoscaler{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewPDB", mock.Anything).Return(
+					&kpolicyv1.PodDisruptionBudget{}, nil).Once()
+				testEnv.kubeClient.On("GetPodDisruptionBudget", mock.Anything, mock.Anything,
+					mock.Anything).Return(&kpolicyv1.PodDisruptionBudget{}, nil).Once()
+				testEnv.backendManager.On("GenerateNewNetworkPolicy", mock.Anything, mock.Anything).Return(
+					nil, nil).Once()
+				testEnv.kubeClient.On("GetNetworkPolicy", mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Once()
+			},
+			wantConditionReason: kcmv1alpha1.ConditionReasonBackendResourcesSynced,
+		},
+		{
+			name: "should stop and set the failed resource in the condition",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GenerateNewService", mock.Anything).Return(&kcorev1.Service{}, nil).Once()
+				testEnv.kubeClient.On("GetService", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil, errTest).Once()
+			},
+			wantError:            errTest,
+			wantConditionReason:  kcmv1alpha1.ConditionReasonBackendResourcesSyncFailed,
+			wantConditionMessage: "failed to sync Service: test error",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv)
+
+			// when
+			err := testEnv.Reconciler.reconcileBackendResources(context.TODO(), givenCompanion,
+				&kcorev1.Secret{}, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeBackendResourcesSynced,
+				tc.wantConditionReason)
+			if tc.wantConditionMessage != "" {
+				condition := meta.FindStatusCondition(givenCompanion.Status.Conditions,
+					kcmv1alpha1.ConditionTypeBackendResourcesSynced)
+				require.Equal(t, tc.wantConditionMessage, condition.Message)
+			}
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handleCompanionDeletion(t *testing.T) {
+	t.Parallel()
+
+	givenDeployment := &kappsv1.Deployment{
+		TypeMeta:   kmetav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: kmetav1.ObjectMeta{Name: "test-deployment", Namespace: "test-namespace"},
+	}
+	givenSecret := &kcorev1.Secret{
+		TypeMeta:   kmetav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: kmetav1.ObjectMeta{Name: "test-secret", Namespace: "test-namespace"},
+	}
+	givenAPIRule := kcmk8sapirule.NewAPIRule(backendmanager.BackendResourceName, "test-namespace")
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenFinalizer          bool
+		givenOlderCompanion     bool
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment)
+		wantError               error
+		wantRequeue             bool
+		wantCompanionDeleted    bool
+		wantStateDeleting       bool
+		wantEventReasons        []string
+	}{
+		{
+			name:                    "should skip the deletion when the finalizer is not set",
+			givenFinalizer:          false,
+			givenMocksBehaviourFunc: func(_ *MockedUnitTestEnvironment) {},
+		},
+		{
+			name:           "should delete the existing resources and keep the finalizer until they are gone",
+			givenFinalizer: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GetManagedResources", mock.Anything).Return(
+					[]client.Object{givenDeployment, givenSecret}).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, givenDeployment).Return(true, nil).Once()
+				testEnv.kubeClient.On("DeleteResource", mock.Anything, givenDeployment).Return(nil).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, givenSecret).Return(false, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources))
+			},
+			wantRequeue:       true,
+			wantStateDeleting: true,
+		},
+		{
+			name:           "should delete the existing exposure resources and keep the finalizer until they are gone",
+			givenFinalizer: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GetManagedResources", mock.Anything).Return(
+					[]client.Object{givenDeployment, givenSecret}).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, mock.Anything).Return(false, nil).Twice()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(givenAPIRule, nil).Once()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources) - 1)
+				testEnv.kubeClient.On("DeleteUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					givenAPIRule.GetName(), givenAPIRule.GetNamespace()).Return(nil).Once()
+			},
+			wantRequeue:       true,
+			wantStateDeleting: true,
+		},
+		{
+			name:           "should remove the finalizer when all resources are gone",
+			givenFinalizer: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GetManagedResources", mock.Anything).Return(
+					[]client.Object{givenDeployment, givenSecret}).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, mock.Anything).Return(false, nil).Twice()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Times(len(backendmanager.ExposureResources))
+			},
+			wantCompanionDeleted: true,
+			wantEventReasons:     []string{EventReasonDeletionCompleted},
+		},
+		{
+			name:           "should return error and keep the finalizer when an exposure resource cannot be deleted",
+			givenFinalizer: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GetManagedResources", mock.Anything).Return(
+					[]client.Object{givenDeployment, givenSecret}).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, mock.Anything).Return(false, nil).Twice()
+				testEnv.kubeClient.On("GetUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					mock.Anything, mock.Anything).Return(givenAPIRule, nil).Once()
+				testEnv.kubeClient.On("DeleteUnstructured", mock.Anything, kcmk8sapirule.GroupVersionResource,
+					givenAPIRule.GetName(), givenAPIRule.GetNamespace()).Return(errTest).Once()
+			},
+			wantError:         errTest,
+			wantStateDeleting: true,
+		},
+		{
+			name:                    "should keep the managed resources when the Companion CR is not the active one",
+			givenFinalizer:          true,
+			givenOlderCompanion:     true,
+			givenMocksBehaviourFunc: func(_ *MockedUnitTestEnvironment) {},
+			wantCompanionDeleted:    true,
+		},
+		{
+			name:           "should return error and keep the finalizer when a resource cannot be deleted",
+			givenFinalizer: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.backendManager.On("GetManagedResources", mock.Anything).Return(
+					[]client.Object{givenDeployment, givenSecret}).Once()
+				testEnv.kubeClient.On("ResourceExists", mock.Anything, givenDeployment).Return(true, nil).Once()
+				testEnv.kubeClient.On("DeleteResource", mock.Anything, givenDeployment).Return(errTest).Once()
+			},
+			wantError:         errTest,
+			wantStateDeleting: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			if tc.givenFinalizer {
+				givenCompanion = testutils.NewCompanionCR(testutils.WithCompanionCRFinalizer(FinalizerName))
+			}
+			givenObjects := []client.Object{givenCompanion}
+			if tc.givenOlderCompanion {
+				givenCompanion.CreationTimestamp = kmetav1.Now()
+				olderCompanion := testutils.NewCompanionCR()
+				olderCompanion.CreationTimestamp = kmetav1.NewTime(time.Now().Add(-time.Hour))
+				givenObjects = append(givenObjects, olderCompanion)
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenObjects...)
+			if tc.givenFinalizer {
+				// mark the CR for deletion, which is only possible while it has a finalizer.
+				require.NoError(t, testEnv.Client.Delete(context.Background(), givenCompanion))
+				latestCompanion, err := testEnv.GetCompanion(givenCompanion.GetName(), givenCompanion.GetNamespace())
+				require.NoError(t, err)
+				givenCompanion = &latestCompanion
+			}
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv)
+
+			// when
+			result, err := testEnv.Reconciler.handleCompanionDeletion(context.Background(), givenCompanion,
+				testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			require.Equal(t, tc.wantRequeue, result.RequeueAfter > 0)
+			gotCompanion, err := testEnv.GetCompanion(givenCompanion.GetName(), givenCompanion.GetNamespace())
+			if tc.wantCompanionDeleted {
+				require.True(t, kapierrors.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.wantStateDeleting {
+				require.Equal(t, kcmv1alpha1.StateDeleting, gotCompanion.Status.State)
+				require.Contains(t, gotCompanion.GetFinalizers(), FinalizerName)
+				requireConditionReason(t, &gotCompanion, kcmv1alpha1.ConditionTypeReady,
+					kcmv1alpha1.ConditionReasonDeleting)
+			}
+			requireEventReasons(t, testEnv.Recorder, tc.wantEventReasons)
+			testEnv.backendManager.AssertExpectations(t)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlePausedReconcile(t *testing.T) {
+	t.Parallel()
+
+	availableDeployment := testutils.NewCompanionDeployment(backendmanager.BackendResourceName, "test-namespace")
+	availableDeployment.Status = kappsv1.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		AvailableReplicas: 1,
+		Conditions: []kappsv1.DeploymentCondition{
+			{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionTrue},
+		},
+	}
+
+	// define test cases
+	testCases := []struct {
+		name                    string
+		givenUntil              *time.Time
+		givenAlreadyPaused      bool
+		givenMocksBehaviourFunc func(testEnv *MockedUnitTestEnvironment)
+		wantError               error
+		wantMaxRequeue          time.Duration
+		wantDeploymentReason    string
+		wantEventReasons        []string
+	}{
+		{
+			name: "should only reflect the status of the existing deployment",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.kubeClient.On("GetDeployment", mock.Anything, backendmanager.BackendResourceName,
+					mock.Anything).Return(availableDeployment, nil).Once()
+			},
+			wantDeploymentReason: kcmv1alpha1.ConditionReasonDeploymentAvailable,
+			wantEventReasons:     []string{EventReasonReconcilePaused},
+		},
+		{
+			name:               "should not emit an Event when the reconciliation was already paused",
+			givenAlreadyPaused: true,
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.kubeClient.On("GetDeployment", mock.Anything, mock.Anything,
+					mock.Anything).Return(availableDeployment, nil).Once()
+			},
+			wantDeploymentReason: kcmv1alpha1.ConditionReasonDeploymentAvailable,
+		},
+		{
+			name:       "should requeue when the pause expires",
+			givenUntil: ptr.To(time.Now().Add(time.Minute)),
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.kubeClient.On("GetDeployment", mock.Anything, mock.Anything,
+					mock.Anything).Return(availableDeployment, nil).Once()
+			},
+			wantMaxRequeue:       time.Minute,
+			wantDeploymentReason: kcmv1alpha1.ConditionReasonDeploymentAvailable,
+			wantEventReasons:     []string{EventReasonReconcilePaused},
+		},
+		{
+			name: "should not create the deployment when it does not exist",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.kubeClient.On("GetDeployment", mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, nil).Once()
+			},
+			wantMaxRequeue:       deploymentNotAvailableRequeueDelay,
+			wantDeploymentReason: kcmv1alpha1.ConditionReasonDeploymentNotAvailable,
+			wantEventReasons:     []string{EventReasonReconcilePaused},
+		},
+		{
+			name: "should return error when the deployment cannot be fetched",
+			givenMocksBehaviourFunc: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.kubeClient.On("GetDeployment", mock.Anything, mock.Anything,
+					mock.Anything).Return(nil, errTest).Once()
+			},
+			wantError:            errTest,
+			wantDeploymentReason: kcmv1alpha1.ConditionReasonDeploymentSyncFailed,
+			wantEventReasons:     []string{EventReasonReconcilePaused},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR(testutils.WithAnnotations(map[string]string{
+				kcmv1alpha1.ReconcileAnnotation: kcmv1alpha1.ReconcilePaused,
+			}))
+			if tc.givenAlreadyPaused {
+				givenCompanion.SetCondition(kcmv1alpha1.ConditionTypePaused, kmetav1.ConditionTrue,
+					kcmv1alpha1.ConditionReasonReconcilePaused, "")
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// define mocks behaviour
+			tc.givenMocksBehaviourFunc(testEnv)
+
+			// when
+			result, err := testEnv.Reconciler.handlePausedReconcile(context.Background(), givenCompanion,
+				tc.givenUntil, testEnv.Logger)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			if tc.wantMaxRequeue == 0 {
+				require.Zero(t, result.RequeueAfter)
+			} else {
+				require.Positive(t, result.RequeueAfter)
+				require.LessOrEqual(t, result.RequeueAfter, tc.wantMaxRequeue)
+			}
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypePaused,
+				kcmv1alpha1.ConditionReasonReconcilePaused)
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypeDeploymentAvailable,
+				tc.wantDeploymentReason)
+			requireEventReasons(t, testEnv.Recorder, tc.wantEventReasons)
+
+			// the managed resources must not be changed.
+			testEnv.kubeClient.AssertNotCalled(t, "PatchApply", mock.Anything, mock.Anything)
+			testEnv.kubeClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_resumeReconcile(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name             string
+		givenPaused      bool
+		wantEventReasons []string
+	}{
+		{
+			name:             "should remove the Paused condition and emit an Event when the pause ends",
+			givenPaused:      true,
+			wantEventReasons: []string{EventReasonReconcileResumed},
+		},
+		{
+			name: "should do nothing when the reconciliation was not paused",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := testutils.NewCompanionCR()
+			if tc.givenPaused {
+				givenCompanion.SetCondition(kcmv1alpha1.ConditionTypePaused, kmetav1.ConditionTrue,
+					kcmv1alpha1.ConditionReasonReconcilePaused, "")
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+
+			// when
+			testEnv.Reconciler.resumeReconcile(givenCompanion, testEnv.Logger)
+
+			// then
+			requireConditionReason(t, givenCompanion, kcmv1alpha1.ConditionTypePaused, "")
+			requireEventReasons(t, testEnv.Recorder, tc.wantEventReasons)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package controller
+
+// Event reasons of the Events emitted on the Companion CR.
+const (
+	EventReasonBackendSecretCreated     = "BackendSecretCreated"
+	EventReasonBackendSecretUpdated     = "BackendSecretUpdated"
+	EventReasonDeploymentApplied        = "DeploymentApplied"
+	EventReasonSecretNotFound           = "SecretNotFound"
+	EventReasonSecretInvalid            = "SecretInvalid"
+	EventReasonDeploymentRolloutStalled = "DeploymentRolloutStalled"
+	EventReasonDeletionCompleted        = "DeletionCompleted"
+	EventReasonReconcilePaused          = "ReconcilePaused"
+	EventReasonReconcileResumed         = "ReconcileResumed"
+)
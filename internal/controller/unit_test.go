@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -17,10 +18,15 @@ import (
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	backendmanagermocks "github.com/kyma-project/kyma-companion-manager/internal/backendmanager/mocks"
+	kcmmetrics "github.com/kyma-project/kyma-companion-manager/internal/metrics"
+	"github.com/kyma-project/kyma-companion-manager/pkg/equality"
 	kcmk8smocks "github.com/kyma-project/kyma-companion-manager/pkg/k8s/mocks"
 	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
 )
 
+// fakeRecorderBufferSize is the number of Events which can be recorded without blocking.
+const fakeRecorderBufferSize = 100
+
 // MockedUnitTestEnvironment provides mocked resources for unit tests.
 type MockedUnitTestEnvironment struct {
 	Client         client.Client
@@ -48,13 +54,16 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 	require.NoError(t, err)
 	err = kappsv1.AddToScheme(newScheme)
 	require.NoError(t, err)
+	err = kautoscalingv2.AddToScheme(newScheme)
+	require.NoError(t, err)
 
 	// Create k8s client.
-	fakeClientBuilder := fake.NewClientBuilder().WithScheme(newScheme)
+	fakeClientBuilder := fake.NewClientBuilder().WithScheme(newScheme).
+		WithIndex(&kcmv1alpha1.Companion{}, secretRefsIndexKey, secretRefsIndexer)
 	fakeClient := fakeClientBuilder.WithObjects(objs...).WithStatusSubresource(objs...).Build()
 
 	// fake recorder.
-	recorder := &record.FakeRecorder{}
+	recorder := record.NewFakeRecorder(fakeRecorderBufferSize)
 
 	// setup custom mocks
 	backendManager := new(backendmanagermocks.Manager)
@@ -65,8 +74,11 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 		Client:         fakeClient,
 		Scheme:         newScheme,
 		logger:         logger,
+		recorder:       recorder,
+		metrics:        kcmmetrics.NewCollector(),
 		kubeClient:     kubeClient,
 		backendManager: backendManager,
+		driftDetector:  equality.NewSemanticDriftDetector(),
 	}
 
 	return &MockedUnitTestEnvironment{
@@ -2,11 +2,21 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
+	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
 	kctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	"github.com/kyma-project/kyma-companion-manager/pkg/equality"
 )
 
 func (r *Reconciler) containsFinalizer(companion *kcmv1alpha1.Companion) bool {
@@ -29,3 +39,222 @@ func (r *Reconciler) removeFinalizer(ctx context.Context, companion *kcmv1alpha1
 
 	return kctrl.Result{}, nil
 }
+
+// syncCompanionStatus derives the state of the Companion CR from its conditions and updates
+// the status, if it has changed.
+func (r *Reconciler) syncCompanionStatus(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	companion.UpdateStateFromConditions()
+	return r.updateCompanionStatus(ctx, companion, log)
+}
+
+// updateCompanionStatus updates the status of the Companion CR as it is, if it has changed.
+func (r *Reconciler) updateCompanionStatus(ctx context.Context, companion *kcmv1alpha1.Companion,
+	log *zap.SugaredLogger,
+) error {
+	r.metrics.SetCompanionState(companion)
+
+	// fetch the latest CR to compare the status with.
+	latestCompanion := &kcmv1alpha1.Companion{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(companion), latestCompanion); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if latestCompanion.Status.IsEqual(companion.Status) {
+		return nil
+	}
+
+	log.Infof("updating Companion status to state: %s", companion.Status.State)
+	latestCompanion.Status = companion.Status
+	return r.Status().Update(ctx, latestCompanion)
+}
+
+// recordDrift logs the fields of a managed resource which drifted from their expected values at debug level
+// and counts their bounded path prefixes in the drift metric.
+func (r *Reconciler) recordDrift(kind string, diffs []equality.FieldDiff, log *zap.SugaredLogger) {
+	if len(diffs) == 0 {
+		return
+	}
+	log.Debugw("detected drift of the managed resource", "kind", kind, "diff", equality.Strings(diffs))
+	r.metrics.RecordDrift(kind, equality.PathPrefixes(diffs))
+}
+
+// isDeploymentAvailable returns true if the rollout of the given deployment is complete
+// and the deployment has the Available condition set to true.
+func isDeploymentAvailable(deployment *kappsv1.Deployment) bool {
+	if deployment == nil {
+		return false
+	}
+
+	// the rollout is in progress as long as the latest spec is not observed or old replicas exist.
+	status := deployment.Status
+	if status.ObservedGeneration < deployment.GetGeneration() ||
+		status.UpdatedReplicas != status.Replicas || status.AvailableReplicas == 0 {
+		return false
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type == kappsv1.DeploymentAvailable {
+			return condition.Status == kcorev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getPodFailureReason returns the reason of the first failing container of the given pods.
+// It returns an empty string, if no container is failing.
+func getPodFailureReason(pods []kcorev1.Pod) string {
+	for _, pod := range pods {
+		containerStatuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses)
+		for _, containerStatus := range containerStatuses {
+			waiting := containerStatus.State.Waiting
+			if waiting == nil || !podFailureReasons[waiting.Reason] {
+				continue
+			}
+			reason := fmt.Sprintf("container %s of pod %s is in %s", containerStatus.Name, pod.GetName(),
+				waiting.Reason)
+			if waiting.Message != "" {
+				reason = fmt.Sprintf("%s (%s)", reason, waiting.Message)
+			}
+			return reason
+		}
+	}
+	return ""
+}
+
+// isDeploymentRolloutStalled returns true if the rollout of the given deployment exceeded its progress deadline.
+func isDeploymentRolloutStalled(deployment *kappsv1.Deployment) bool {
+	if deployment == nil {
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == kappsv1.DeploymentProgressing {
+			return condition.Status == kcorev1.ConditionFalse && condition.Reason == deploymentProgressDeadlineExceeded
+		}
+	}
+	return false
+}
+
+// secretRefsIndexer returns the index values of the secrets and configMaps referenced in the given Companion CR.
+// The configMap of AI Core has the same name and namespace as the secret of AI Core.
+func secretRefsIndexer(obj client.Object) []string {
+	companion, ok := obj.(*kcmv1alpha1.Companion)
+	if !ok {
+		return nil
+	}
+	return []string{
+		secretRefIndexValue(companion.Spec.AICore.Secret.Namespace, companion.Spec.AICore.Secret.Name),
+		secretRefIndexValue(companion.Spec.HanaCloud.Secret.Namespace, companion.Spec.HanaCloud.Secret.Name),
+		secretRefIndexValue(companion.Spec.Redis.Secret.Namespace, companion.Spec.Redis.Secret.Name),
+	}
+}
+
+func secretRefIndexValue(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// mapReferencedObjectToCompanions returns reconcile requests for the Companion CRs which reference
+// the given secret or configMap.
+func (r *Reconciler) mapReferencedObjectToCompanions(ctx context.Context, obj client.Object) []reconcile.Request {
+	companions := &kcmv1alpha1.CompanionList{}
+	err := r.List(ctx, companions, client.MatchingFields{
+		secretRefsIndexKey: secretRefIndexValue(obj.GetNamespace(), obj.GetName()),
+	})
+	if err != nil {
+		r.logger.Errorw("failed to list Companion CRs referencing the object", "error", err,
+			"namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(companions.Items))
+	for _, companion := range companions.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&companion)})
+	}
+	return requests
+}
+
+// getActiveCompanion returns the active Companion CR in the cluster. As the backend resources can only exist once,
+// the oldest Companion CR is elected as the active one. The given Companion CR is taken into account as well,
+// in case it is not yet in the cache. A Companion CR which is being deleted stays active until its finalizer is
+// removed, i.e. until its managed resources are deleted, see handleCompanionDeletion. So, the next Companion CR
+// only takes over once the resources of the previous one, which may be in another namespace, are gone.
+func (r *Reconciler) getActiveCompanion(ctx context.Context,
+	companion *kcmv1alpha1.Companion,
+) (*kcmv1alpha1.Companion, error) {
+	companions := &kcmv1alpha1.CompanionList{}
+	if err := r.List(ctx, companions); err != nil {
+		return nil, err
+	}
+
+	activeCompanion := companion
+	for i := range companions.Items {
+		if isOlderCompanion(&companions.Items[i], activeCompanion) {
+			activeCompanion = &companions.Items[i]
+		}
+	}
+	return activeCompanion, nil
+}
+
+// isOlderCompanion returns true if the Companion CR a was created before b. Companion CRs with the same
+// creation timestamp are ordered by their namespace and name, so the election result is stable.
+func isOlderCompanion(a, b *kcmv1alpha1.Companion) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return client.ObjectKeyFromObject(a).String() < client.ObjectKeyFromObject(b).String()
+}
+
+// podFailureReasons are the reasons of waiting containers which do not recover without user interaction.
+//
+//nolint:gochecknoglobals // used as constant.
+var podFailureReasons = map[string]bool{
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// isSameCompanion returns true if both Companion CRs have the same namespace and name.
+func isSameCompanion(a, b *kcmv1alpha1.Companion) bool {
+	return client.ObjectKeyFromObject(a) == client.ObjectKeyFromObject(b)
+}
+
+// mapCompanionToOtherCompanions returns reconcile requests for all the other Companion CRs,
+// so a new active Companion CR is elected when the given one is gone.
+func (r *Reconciler) mapCompanionToOtherCompanions(ctx context.Context, obj client.Object) []reconcile.Request {
+	companions := &kcmv1alpha1.CompanionList{}
+	if err := r.List(ctx, companions); err != nil {
+		r.logger.Errorw("failed to list Companion CRs", "error", err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(companions.Items))
+	for _, companion := range companions.Items {
+		if client.ObjectKeyFromObject(&companion) == client.ObjectKeyFromObject(obj) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&companion)})
+	}
+	return requests
+}
+
+// companionDeletedPredicate only accepts the delete events.
+func companionDeletedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// objectName returns the namespace and the name of the given object, or only its name if it is cluster-scoped.
+func objectName(obj client.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -3,8 +3,14 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
 	"github.com/kyma-project/kyma-companion-manager/test/utils"
@@ -80,3 +86,468 @@ func Test_removeFinalizer(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, reconciler.containsFinalizer(&gotCompanion))
 }
+
+func Test_syncCompanionStatus(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenConditions []kmetav1.Condition
+		wantState       string
+		wantReadyStatus kmetav1.ConditionStatus
+		wantReadyReason string
+	}{
+		{
+			name:            "should set processing state when no condition is set",
+			wantState:       kcmv1alpha1.StateProcessing,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: kcmv1alpha1.ConditionReasonProcessing,
+		},
+		{
+			name: "should set warning state when a referenced secret is missing",
+			givenConditions: []kmetav1.Condition{
+				{
+					Type:   kcmv1alpha1.ConditionTypeSecretsResolved,
+					Status: kmetav1.ConditionFalse,
+					Reason: kcmv1alpha1.ConditionReasonSecretNotFound,
+				},
+			},
+			wantState:       kcmv1alpha1.StateWarning,
+			wantReadyStatus: kmetav1.ConditionFalse,
+			wantReadyReason: kcmv1alpha1.ConditionReasonSecretNotFound,
+		},
+		{
+			name: "should set ready state when all conditions are true",
+			givenConditions: []kmetav1.Condition{
+				{
+					Type:   kcmv1alpha1.ConditionTypeActive,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonActive,
+				},
+				{
+					Type:   kcmv1alpha1.ConditionTypeSpecValid,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonSpecValid,
+				},
+				{
+					Type:   kcmv1alpha1.ConditionTypeSecretsResolved,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonSecretsResolved,
+				},
+				{
+					Type:   kcmv1alpha1.ConditionTypeBackendSecretSynced,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonBackendSecretSynced,
+				},
+				{
+					Type:   kcmv1alpha1.ConditionTypeDeploymentAvailable,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonDeploymentAvailable,
+				},
+				{
+					Type:   kcmv1alpha1.ConditionTypeBackendResourcesSynced,
+					Status: kmetav1.ConditionTrue,
+					Reason: kcmv1alpha1.ConditionReasonBackendResourcesSynced,
+				},
+			},
+			wantState:       kcmv1alpha1.StateReady,
+			wantReadyStatus: kmetav1.ConditionTrue,
+			wantReadyReason: kcmv1alpha1.ConditionReasonReady,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion := utils.NewCompanionCR()
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion)
+			for _, condition := range testcase.givenConditions {
+				givenCompanion.SetCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+
+			// when
+			err := testEnv.Reconciler.syncCompanionStatus(context.Background(), givenCompanion, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			gotCompanion, err := testEnv.GetCompanion(givenCompanion.GetName(), givenCompanion.GetNamespace())
+			require.NoError(t, err)
+			require.Equal(t, testcase.wantState, gotCompanion.Status.State)
+			require.Equal(t, gotCompanion.GetGeneration(), gotCompanion.Status.ObservedGeneration)
+			gotReadyCondition := meta.FindStatusCondition(gotCompanion.Status.Conditions,
+				kcmv1alpha1.ConditionTypeReady)
+			require.NotNil(t, gotReadyCondition)
+			require.Equal(t, testcase.wantReadyStatus, gotReadyCondition.Status)
+			require.Equal(t, testcase.wantReadyReason, gotReadyCondition.Reason)
+		})
+	}
+}
+
+func Test_isDeploymentAvailable(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenDeployment *kappsv1.Deployment
+		wantResult      bool
+	}{
+		{
+			name:            "should return false when deployment is nil",
+			givenDeployment: nil,
+			wantResult:      false,
+		},
+		{
+			name:            "should return false when deployment has no conditions",
+			givenDeployment: &kappsv1.Deployment{},
+			wantResult:      false,
+		},
+		{
+			name: "should return false when deployment is not available",
+			givenDeployment: &kappsv1.Deployment{
+				Status: kappsv1.DeploymentStatus{
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionFalse},
+					},
+				},
+			},
+			wantResult: false,
+		},
+		{
+			name: "should return false when old replicas of the rollout still exist",
+			givenDeployment: &kappsv1.Deployment{
+				Status: kappsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 1,
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionTrue},
+					},
+				},
+			},
+			wantResult: false,
+		},
+		{
+			name: "should return false when the latest generation is not yet observed",
+			givenDeployment: &kappsv1.Deployment{
+				ObjectMeta: kmetav1.ObjectMeta{Generation: 2},
+				Status: kappsv1.DeploymentStatus{
+					ObservedGeneration: 1,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionTrue},
+					},
+				},
+			},
+			wantResult: false,
+		},
+		{
+			name: "should return true when deployment is available",
+			givenDeployment: &kappsv1.Deployment{
+				ObjectMeta: kmetav1.ObjectMeta{Generation: 2},
+				Status: kappsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentProgressing, Status: kcorev1.ConditionTrue},
+						{Type: kappsv1.DeploymentAvailable, Status: kcorev1.ConditionTrue},
+					},
+				},
+			},
+			wantResult: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, testcase.wantResult, isDeploymentAvailable(testcase.givenDeployment))
+		})
+	}
+}
+
+func Test_isDeploymentRolloutStalled(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name            string
+		givenDeployment *kappsv1.Deployment
+		wantResult      bool
+	}{
+		{
+			name:            "should return false when deployment is nil",
+			givenDeployment: nil,
+			wantResult:      false,
+		},
+		{
+			name: "should return false when deployment is progressing",
+			givenDeployment: &kappsv1.Deployment{
+				Status: kappsv1.DeploymentStatus{
+					Conditions: []kappsv1.DeploymentCondition{
+						{Type: kappsv1.DeploymentProgressing, Status: kcorev1.ConditionTrue},
+					},
+				},
+			},
+			wantResult: false,
+		},
+		{
+			name: "should return true when deployment exceeded its progress deadline",
+			givenDeployment: &kappsv1.Deployment{
+				Status: kappsv1.DeploymentStatus{
+					Conditions: []kappsv1.DeploymentCondition{
+						{
+							Type:   kappsv1.DeploymentProgressing,
+							Status: kcorev1.ConditionFalse,
+							Reason: deploymentProgressDeadlineExceeded,
+						},
+					},
+				},
+			},
+			wantResult: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, testcase.wantResult, isDeploymentRolloutStalled(testcase.givenDeployment))
+		})
+	}
+}
+
+func Test_getPodFailureReason(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(name string, initStatuses, statuses []kcorev1.ContainerStatus) kcorev1.Pod {
+		return kcorev1.Pod{
+			ObjectMeta: kmetav1.ObjectMeta{Name: name},
+			Status: kcorev1.PodStatus{
+				InitContainerStatuses: initStatuses,
+				ContainerStatuses:     statuses,
+			},
+		}
+	}
+	waitingStatus := func(reason, message string) []kcorev1.ContainerStatus {
+		return []kcorev1.ContainerStatus{
+			{
+				Name: "backend",
+				State: kcorev1.ContainerState{
+					Waiting: &kcorev1.ContainerStateWaiting{Reason: reason, Message: message},
+				},
+			},
+		}
+	}
+
+	// define test cases
+	testCases := []struct {
+		name       string
+		givenPods  []kcorev1.Pod
+		wantReason string
+	}{
+		{
+			name:       "should return empty reason when there are no pods",
+			wantReason: "",
+		},
+		{
+			name:       "should return empty reason when the containers are only starting",
+			givenPods:  []kcorev1.Pod{newPod("pod-1", nil, waitingStatus("ContainerCreating", ""))},
+			wantReason: "",
+		},
+		{
+			name: "should return the reason of a container in CrashLoopBackOff",
+			givenPods: []kcorev1.Pod{
+				newPod("pod-1", nil, nil),
+				newPod("pod-2", nil, waitingStatus("CrashLoopBackOff", "back-off restarting failed container")),
+			},
+			wantReason: "container backend of pod pod-2 is in CrashLoopBackOff (back-off restarting failed container)",
+		},
+		{
+			name:       "should return the reason of a failing init container",
+			givenPods:  []kcorev1.Pod{newPod("pod-1", waitingStatus("ImagePullBackOff", ""), nil)},
+			wantReason: "container backend of pod pod-1 is in ImagePullBackOff",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, testcase.wantReason, getPodFailureReason(testcase.givenPods))
+		})
+	}
+}
+
+func Test_secretRefsIndexer(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion := utils.NewCompanionCR(
+		utils.WithAICoreSecret("ai-core", "ai-core-ns"),
+		utils.WithHanaCloudSecret("hana", "hana-ns"),
+		utils.WithRedisSecret("redis", "redis-ns"),
+	)
+
+	// when
+	got := secretRefsIndexer(givenCompanion)
+
+	// then
+	require.Equal(t, []string{"ai-core-ns/ai-core", "hana-ns/hana", "redis-ns/redis"}, got)
+	require.Nil(t, secretRefsIndexer(&kcorev1.Secret{}))
+}
+
+func Test_mapReferencedObjectToCompanions(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name         string
+		givenObject  client.Object
+		wantRequests []string
+	}{
+		{
+			name: "should map a referenced secret to all Companion CRs referencing it",
+			givenObject: &kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "hana", Namespace: "hana-ns"},
+			},
+			wantRequests: []string{"companion-1", "companion-2"},
+		},
+		{
+			name: "should map a referenced configMap to the Companion CR referencing it",
+			givenObject: &kcorev1.ConfigMap{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "ai-core-2", Namespace: "ai-core-ns"},
+			},
+			wantRequests: []string{"companion-2"},
+		},
+		{
+			name: "should not map an object which is not referenced",
+			givenObject: &kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "hana", Namespace: "other-ns"},
+			},
+			wantRequests: []string{},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenCompanion1 := utils.NewCompanionCR(
+				utils.WithAICoreSecret("ai-core-1", "ai-core-ns"),
+				utils.WithHanaCloudSecret("hana", "hana-ns"),
+				utils.WithRedisSecret("redis", "redis-ns"),
+			)
+			givenCompanion1.Name = "companion-1"
+			givenCompanion2 := givenCompanion1.DeepCopy()
+			givenCompanion2.Name = "companion-2"
+			givenCompanion2.Spec.AICore.Secret.Name = "ai-core-2"
+			testEnv := NewMockedUnitTestEnvironment(t, givenCompanion1, givenCompanion2)
+
+			// when
+			gotRequests := testEnv.Reconciler.mapReferencedObjectToCompanions(context.Background(),
+				testcase.givenObject)
+
+			// then
+			gotNames := make([]string, 0, len(gotRequests))
+			for _, request := range gotRequests {
+				gotNames = append(gotNames, request.Name)
+			}
+			require.ElementsMatch(t, testcase.wantRequests, gotNames)
+		})
+	}
+}
+
+func Test_isOlderCompanion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	// define test cases
+	testCases := []struct {
+		name       string
+		givenA     *kcmv1alpha1.Companion
+		givenB     *kcmv1alpha1.Companion
+		wantResult bool
+	}{
+		{
+			name:       "should be older when it was created before",
+			givenA:     newCompanionWithCreationTime("a", "default", now.Add(-time.Minute)),
+			givenB:     newCompanionWithCreationTime("b", "default", now),
+			wantResult: true,
+		},
+		{
+			name:       "should not be older when it was created after",
+			givenA:     newCompanionWithCreationTime("a", "default", now),
+			givenB:     newCompanionWithCreationTime("b", "default", now.Add(-time.Minute)),
+			wantResult: false,
+		},
+		{
+			name:       "should be ordered by namespace and name when created at the same time",
+			givenA:     newCompanionWithCreationTime("b", "default", now),
+			givenB:     newCompanionWithCreationTime("a", "kyma-system", now),
+			wantResult: true,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when, then
+			require.Equal(t, testcase.wantResult, isOlderCompanion(testcase.givenA, testcase.givenB))
+		})
+	}
+}
+
+func Test_mapCompanionToOtherCompanions(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenCompanion1 := utils.NewCompanionCR()
+	givenCompanion2 := utils.NewCompanionCR()
+	givenCompanion3 := utils.NewCompanionCR()
+	testEnv := NewMockedUnitTestEnvironment(t, givenCompanion1, givenCompanion2, givenCompanion3)
+
+	// when
+	gotRequests := testEnv.Reconciler.mapCompanionToOtherCompanions(context.Background(), givenCompanion1)
+
+	// then
+	gotNames := make([]string, 0, len(gotRequests))
+	for _, request := range gotRequests {
+		gotNames = append(gotNames, request.Name)
+	}
+	require.ElementsMatch(t, []string{givenCompanion2.GetName(), givenCompanion3.GetName()}, gotNames)
+}
+
+func newCompanionWithCreationTime(name, namespace string, creationTime time.Time) *kcmv1alpha1.Companion {
+	companion := utils.NewCompanionCR()
+	companion.Name = name
+	companion.Namespace = namespace
+	companion.CreationTimestamp = kmetav1.NewTime(creationTime)
+	return companion
+}
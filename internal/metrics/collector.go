@@ -0,0 +1,239 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+)
+
+const (
+	metricsNamespace = "kyma_companion_manager"
+
+	// PhaseSecret is the reconciliation phase of the backend secret.
+	PhaseSecret = "secret"
+	// PhaseDeployment is the reconciliation phase of the backend deployment.
+	PhaseDeployment = "deployment"
+	// PhaseDeletion is the reconciliation phase of the deletion of the Companion CR.
+	PhaseDeletion = "deletion"
+
+	// KindDeployment is the kind of the backend deployment, of which the drift is counted.
+	KindDeployment = "Deployment"
+	// KindSecret is the kind of the backend secret, of which the drift is counted.
+	KindSecret = "Secret"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+
+	labelPhase              = "phase"
+	labelResult             = "result"
+	labelState              = "state"
+	labelKind               = "kind"
+	labelField              = "field"
+	labelCompanionName      = "companion_name"
+	labelCompanionNamespace = "companion_namespace"
+	labelSecretName         = "secret_name"
+	labelSecretNamespace    = "secret_namespace"
+)
+
+// states are all the states a Companion CR can have, so the state gauge is reset for the previous state.
+//
+//nolint:gochecknoglobals // used as constant.
+var states = []string{
+	kcmv1alpha1.StateReady,
+	kcmv1alpha1.StateProcessing,
+	kcmv1alpha1.StateWarning,
+	kcmv1alpha1.StateError,
+	kcmv1alpha1.StateDeleting,
+}
+
+// Collector holds the custom metrics of the kyma-companion-manager.
+type Collector struct {
+	reconcileTotal     *prometheus.CounterVec
+	secretSyncDuration prometheus.Histogram
+	companionState     *prometheus.GaugeVec
+	readyReplicas      *prometheus.GaugeVec
+	credentialsAge     *credentialsAgeCollector
+	driftTotal         *prometheus.CounterVec
+}
+
+// NewCollector creates a new Collector with all the custom metrics.
+func NewCollector() *Collector {
+	return &Collector{
+		reconcileTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "reconcile_total",
+				Help:      "The number of reconciliations per phase and result.",
+			},
+			[]string{labelPhase, labelResult},
+		),
+		secretSyncDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "secret_sync_duration_seconds",
+				Help:      "The duration of the synchronization of the backend secret.",
+				Buckets:   prometheus.DefBuckets,
+			},
+		),
+		companionState: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "companion_state",
+				Help:      "The state of the Companion CR. The gauge of the current state is 1, all others are 0.",
+			},
+			[]string{labelCompanionNamespace, labelCompanionName, labelState},
+		),
+		readyReplicas: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "backend_ready_replicas",
+				Help:      "The number of ready replicas of the companion backend.",
+			},
+			[]string{labelCompanionNamespace, labelCompanionName},
+		),
+		credentialsAge: newCredentialsAgeCollector(),
+		driftTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "drift_total",
+				Help:      "The number of times a field of a managed resource drifted from its expected value.",
+			},
+			[]string{labelKind, labelField},
+		),
+	}
+}
+
+// RegisterMetrics registers all the custom metrics with the given registry.
+func (c *Collector) RegisterMetrics(registry prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		c.reconcileTotal,
+		c.secretSyncDuration,
+		c.companionState,
+		c.readyReplicas,
+		c.credentialsAge,
+		c.driftTotal,
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordReconcileOutcome counts the result of a reconciliation of the given phase.
+func (c *Collector) RecordReconcileOutcome(phase string, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+	c.reconcileTotal.WithLabelValues(phase, result).Inc()
+}
+
+// ObserveSecretSyncDuration records the duration of a synchronization of the backend secret.
+func (c *Collector) ObserveSecretSyncDuration(duration time.Duration) {
+	c.secretSyncDuration.Observe(duration.Seconds())
+}
+
+// RecordDrift counts the drift of the given fields of a managed resource of the given kind. The fields must be
+// bounded, e.g. by equality.PathPrefix, to keep the cardinality of the metric low.
+func (c *Collector) RecordDrift(kind string, fields []string) {
+	for _, field := range fields {
+		c.driftTotal.WithLabelValues(kind, field).Inc()
+	}
+}
+
+// SetCompanionState sets the state gauge of the given Companion CR to the given state.
+func (c *Collector) SetCompanionState(companion *kcmv1alpha1.Companion) {
+	for _, state := range states {
+		value := 0.0
+		if state == companion.Status.State {
+			value = 1
+		}
+		c.companionState.WithLabelValues(companion.GetNamespace(), companion.GetName(), state).Set(value)
+	}
+}
+
+// SetReadyReplicas sets the number of ready replicas of the backend of the given Companion CR.
+func (c *Collector) SetReadyReplicas(companion *kcmv1alpha1.Companion, readyReplicas int32) {
+	c.readyReplicas.WithLabelValues(companion.GetNamespace(), companion.GetName()).Set(float64(readyReplicas))
+}
+
+// SetCredentialsLastModified sets the time of the last modification of the given source secret.
+// The age of the credentials is computed from it when the metrics are scraped.
+func (c *Collector) SetCredentialsLastModified(namespace, name string, lastModified time.Time) {
+	c.credentialsAge.set(namespace, name, lastModified)
+}
+
+// RemoveCompanion removes the metrics of the Companion CR with the given namespace and name.
+func (c *Collector) RemoveCompanion(namespace, name string) {
+	labels := prometheus.Labels{
+		labelCompanionNamespace: namespace,
+		labelCompanionName:      name,
+	}
+	c.companionState.DeletePartialMatch(labels)
+	c.readyReplicas.DeletePartialMatch(labels)
+}
+
+// RemoveCredentials removes the age metrics of all the source secrets.
+func (c *Collector) RemoveCredentials() {
+	c.credentialsAge.reset()
+}
+
+// credentialsAgeCollector exposes the age of the source credentials. The age is computed on every scrape,
+// so it keeps growing between the reconciliations.
+type credentialsAgeCollector struct {
+	desc         *prometheus.Desc
+	mutex        sync.RWMutex
+	lastModified map[secretKey]time.Time
+	now          func() time.Time
+}
+
+type secretKey struct {
+	namespace string
+	name      string
+}
+
+func newCredentialsAgeCollector() *credentialsAgeCollector {
+	return &credentialsAgeCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "credentials_age_seconds"),
+			"The time since the last modification of the source secrets of the companion backend.",
+			[]string{labelSecretNamespace, labelSecretName},
+			nil,
+		),
+		lastModified: map[secretKey]time.Time{},
+		now:          time.Now,
+	}
+}
+
+func (c *credentialsAgeCollector) set(namespace, name string, lastModified time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lastModified[secretKey{namespace: namespace, name: name}] = lastModified
+}
+
+func (c *credentialsAgeCollector) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lastModified = map[secretKey]time.Time{}
+}
+
+// Describe implements prometheus.Collector.
+func (c *credentialsAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *credentialsAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	now := c.now()
+	for key, lastModified := range c.lastModified {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(lastModified).Seconds(),
+			key.namespace, key.name)
+	}
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+func Test_RegisterMetrics(t *testing.T) {
+	t.Parallel()
+
+	// given
+	collector := NewCollector()
+	registry := prometheus.NewRegistry()
+
+	// when
+	err := collector.RegisterMetrics(registry)
+
+	// then
+	require.NoError(t, err)
+	require.Error(t, collector.RegisterMetrics(registry), "registering the metrics twice should fail")
+}
+
+func Test_RecordReconcileOutcome(t *testing.T) {
+	t.Parallel()
+
+	// given
+	collector := NewCollector()
+
+	// when
+	collector.RecordReconcileOutcome(PhaseSecret, nil)
+	collector.RecordReconcileOutcome(PhaseSecret, nil)
+	collector.RecordReconcileOutcome(PhaseDeployment, errors.New("test error"))
+
+	// then
+	require.InDelta(t, 2, testutil.ToFloat64(collector.reconcileTotal.WithLabelValues(PhaseSecret, resultSuccess)), 0)
+	require.InDelta(t, 1,
+		testutil.ToFloat64(collector.reconcileTotal.WithLabelValues(PhaseDeployment, resultFailure)), 0)
+	require.InDelta(t, 0,
+		testutil.ToFloat64(collector.reconcileTotal.WithLabelValues(PhaseDeployment, resultSuccess)), 0)
+}
+
+func Test_RecordDrift(t *testing.T) {
+	t.Parallel()
+
+	// given
+	collector := NewCollector()
+
+	// when
+	collector.RecordDrift(KindDeployment, []string{"spec.replicas", "spec.template.spec.containers"})
+	collector.RecordDrift(KindDeployment, []string{"spec.replicas"})
+	collector.RecordDrift(KindSecret, nil)
+
+	// then
+	require.InDelta(t, 2, testutil.ToFloat64(collector.driftTotal.WithLabelValues(KindDeployment, "spec.replicas")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(collector.driftTotal.WithLabelValues(
+		KindDeployment, "spec.template.spec.containers")), 0)
+	require.Equal(t, 2, testutil.CollectAndCount(collector.driftTotal))
+}
+
+func Test_SetCompanionState(t *testing.T) {
+	t.Parallel()
+
+	// given
+	collector := NewCollector()
+	companion := testutils.NewCompanionCR()
+	companion.Status.State = kcmv1alpha1.StateProcessing
+	collector.SetCompanionState(companion)
+
+	// when
+	companion.Status.State = kcmv1alpha1.StateReady
+	collector.SetCompanionState(companion)
+
+	// then
+	require.InDelta(t, 1, testutil.ToFloat64(collector.companionState.WithLabelValues(
+		companion.GetNamespace(), companion.GetName(), kcmv1alpha1.StateReady)), 0)
+	require.InDelta(t, 0, testutil.ToFloat64(collector.companionState.WithLabelValues(
+		companion.GetNamespace(), companion.GetName(), kcmv1alpha1.StateProcessing)), 0)
+}
+
+func Test_RemoveCompanion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	collector := NewCollector()
+	companion := testutils.NewCompanionCR()
+	companion.Status.State = kcmv1alpha1.StateReady
+	collector.SetCompanionState(companion)
+	collector.SetReadyReplicas(companion, 2)
+
+	// when
+	collector.RemoveCompanion(companion.GetNamespace(), companion.GetName())
+
+	// then
+	require.Equal(t, 0, testutil.CollectAndCount(collector.companionState))
+	require.Equal(t, 0, testutil.CollectAndCount(collector.readyReplicas))
+}
+
+func Test_CredentialsAge(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	collector := NewCollector()
+	collector.credentialsAge.now = func() time.Time { return now }
+
+	// when
+	collector.SetCredentialsLastModified("redis-ns", "redis", now.Add(-time.Hour))
+
+	// then
+	wantMetrics := `
+# HELP kyma_companion_manager_credentials_age_seconds The time since the last modification of the source secrets of the companion backend.
+# TYPE kyma_companion_manager_credentials_age_seconds gauge
+kyma_companion_manager_credentials_age_seconds{secret_name="redis",secret_namespace="redis-ns"} 3600
+`
+	require.NoError(t, testutil.CollectAndCompare(collector.credentialsAge, strings.NewReader(wantMetrics)))
+
+	// when
+	collector.RemoveCredentials()
+
+	// then
+	require.Equal(t, 0, testutil.CollectAndCount(collector.credentialsAge))
+}
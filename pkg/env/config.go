@@ -6,10 +6,25 @@ import (
 	"github.com/kelseyhightower/envconfig"
 )
 
+const (
+	// DriftDetectionSemantic detects the drift of the managed resources with hand-written comparators.
+	DriftDetectionSemantic = "semantic"
+	// DriftDetectionDryRun detects the drift of the managed resources with a server-side apply dry-run.
+	DriftDetectionDryRun = "dry-run"
+)
+
 // Config represents the environment config for kyma companion manager.
 type Config struct {
 	// KymaCompanionBackendImage container image for kyma-companion-backend.
 	KymaCompanionBackendImage string `envconfig:"KYMA_COMPANION_BACKEND_IMAGE" required:"true"`
+
+	// EnableWebhooks registers the admission and conversion webhooks for the Companion CR.
+	// Disable it to run the manager locally against a CRD without the conversion webhook.
+	EnableWebhooks bool `envconfig:"ENABLE_WEBHOOKS" default:"true"`
+
+	// DriftDetection selects how the drift of the managed resources is detected, either "semantic" or "dry-run".
+	// The dry-run costs an additional API request per resource and reconciliation, but covers all managed fields.
+	DriftDetection string `envconfig:"DRIFT_DETECTION" default:"semantic"`
 }
 
 func GetConfig() Config {
@@ -17,5 +32,8 @@ func GetConfig() Config {
 	if err := envconfig.Process("", &cfg); err != nil {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
+	if cfg.DriftDetection != DriftDetectionSemantic && cfg.DriftDetection != DriftDetectionDryRun {
+		log.Fatalf("Invalid configuration: unsupported drift detection %q", cfg.DriftDetection)
+	}
 	return cfg
 }
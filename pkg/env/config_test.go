@@ -19,4 +19,7 @@ func Test_GetConfig(t *testing.T) {
 	config := GetConfig()
 	// Ensure required variables can be set
 	g.Expect(config.KymaCompanionBackendImage).To(Equal(envs["KYMA_COMPANION_BACKEND_IMAGE"]))
+	// Ensure optional variables have defaults
+	g.Expect(config.EnableWebhooks).To(BeTrue())
+	g.Expect(config.DriftDetection).To(Equal(DriftDetectionSemantic))
 }
@@ -0,0 +1,283 @@
+package equality
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
+)
+
+// differentValue is shown for objects of the kinds whose diff is not broken down into fields.
+const differentValue = "<different>"
+
+// DriftDetector detects the drift of an existing resource from its expected state.
+type DriftDetector interface {
+	// Diff returns the fields of the existing object which differ from the expected object. The existing
+	// object is up-to-date, if no diffs are returned. A nil existing object differs from any expected object.
+	Diff(ctx context.Context, existing, expected client.Object) ([]FieldDiff, error)
+}
+
+// NewSemanticDriftDetector returns a DriftDetector which compares the objects with Semantic.
+func NewSemanticDriftDetector() DriftDetector {
+	return semanticDriftDetector{}
+}
+
+type semanticDriftDetector struct{}
+
+func (semanticDriftDetector) Diff(_ context.Context, existing, expected client.Object) ([]FieldDiff, error) {
+	switch existing := existing.(type) {
+	case *kappsv1.Deployment:
+		return DeploymentDiff(existing, expected.(*kappsv1.Deployment)), nil //nolint:forcetypeassert // same kind.
+	case *kcorev1.Secret:
+		return SecretDiff(existing, expected.(*kcorev1.Secret)), nil //nolint:forcetypeassert // same kind.
+	}
+
+	if Semantic.DeepEqual(existing, expected) {
+		return nil, nil
+	}
+	if isNil(existing) {
+		return []FieldDiff{objectDiff(existing, expected)}, nil
+	}
+	// the diff of the other kinds is not broken down into fields.
+	return []FieldDiff{{Path: objectPath, Existing: differentValue, Expected: differentValue}}, nil
+}
+
+// NewDryRunDriftDetector returns a DriftDetector which applies the expected object with a server-side apply
+// dry-run and compares the fields owned by the given field manager in the result with the existing object.
+// Unlike Semantic, it covers all the fields set by the manager, and it respects the defaulting of the API server.
+func NewDryRunDriftDetector(kubeClient kcmk8s.Client, fieldManager string) DriftDetector {
+	return dryRunDriftDetector{
+		kubeClient:   kubeClient,
+		fieldManager: fieldManager,
+	}
+}
+
+type dryRunDriftDetector struct {
+	kubeClient   kcmk8s.Client
+	fieldManager string
+}
+
+func (d dryRunDriftDetector) Diff(ctx context.Context, existing, expected client.Object) ([]FieldDiff, error) {
+	if isNil(existing) {
+		return []FieldDiff{objectDiff(existing, expected)}, nil
+	}
+
+	result, err := d.kubeClient.PatchApplyDryRun(ctx, expected)
+	if err != nil {
+		return nil, err
+	}
+	return managedFieldsDiff(existing, result, d.fieldManager)
+}
+
+// managedFieldsDiff returns the fields owned by the given field manager, whose values differ between the existing
+// object and the result of the dry-run. The fields owned in the existing object are compared as well, so the fields
+// which the dry-run removes are reported too. The values of secrets are redacted.
+func managedFieldsDiff(existing, result client.Object, fieldManager string) ([]FieldDiff, error) {
+	resultFields, err := appliedFields(result, fieldManager)
+	if err != nil {
+		return nil, err
+	}
+	if resultFields == nil {
+		return nil, fmt.Errorf("the dry-run result of %s/%s has no fields applied by %s",
+			result.GetNamespace(), result.GetName(), fieldManager)
+	}
+	existingFields, err := appliedFields(existing, fieldManager)
+	if err != nil {
+		return nil, err
+	}
+
+	existingContent, err := toUnstructuredContent(existing)
+	if err != nil {
+		return nil, err
+	}
+	resultContent, err := toUnstructuredContent(result)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isSecret := existing.(*kcorev1.Secret)
+	w := &fieldsWalker{redact: isSecret, diffs: map[string]FieldDiff{}}
+	w.walk(resultFields, existingContent, resultContent, "")
+	w.walk(existingFields, existingContent, resultContent, "")
+
+	var diffs []FieldDiff
+	for _, diff := range w.diffs {
+		diffs = append(diffs, diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+// appliedFields returns the fields which the given field manager applied to the given object, in the FieldsV1
+// format, see https://kubernetes.io/docs/reference/using-api/server-side-apply/#field-management.
+// It returns nil if the field manager did not apply any fields.
+func appliedFields(obj client.Object, fieldManager string) (map[string]interface{}, error) {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.Operation != kmetav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse the managed fields of %s/%s: %w",
+				obj.GetNamespace(), obj.GetName(), err)
+		}
+		return fields, nil
+	}
+	return nil, nil //nolint:nilnil // the field manager did not apply any fields.
+}
+
+func toUnstructuredContent(obj client.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*kunstructured.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+// fieldsWalker walks through a FieldsV1 set and collects the diffs of the fields in the set.
+type fieldsWalker struct {
+	redact bool
+	diffs  map[string]FieldDiff
+}
+
+// walk compares the fields of the given set in the values a and b, which are found at the given path.
+func (w *fieldsWalker) walk(fields map[string]interface{}, a, b interface{}, path string) {
+	for key, child := range fields {
+		childFields, _ := child.(map[string]interface{})
+		switch {
+		case strings.HasPrefix(key, "f:"):
+			name := strings.TrimPrefix(key, "f:")
+			w.compare(childFields, mapField(a, name), mapField(b, name), joinPath(path, name))
+		case strings.HasPrefix(key, "k:"):
+			keys := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &keys); err != nil {
+				continue
+			}
+			w.compare(childFields, listItemByKeys(a, keys), listItemByKeys(b, keys),
+				fmt.Sprintf("%s[%s]", path, formatKeys(keys)))
+		case strings.HasPrefix(key, "v:"):
+			var value interface{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "v:")), &value); err != nil {
+				continue
+			}
+			w.compare(nil, listItemByValue(a, value), listItemByValue(b, value),
+				fmt.Sprintf("%s[%s]", path, strings.TrimPrefix(key, "v:")))
+		case strings.HasPrefix(key, "i:"):
+			index, err := strconv.Atoi(strings.TrimPrefix(key, "i:"))
+			if err != nil {
+				continue
+			}
+			w.compare(childFields, listItemByIndex(a, index), listItemByIndex(b, index),
+				fmt.Sprintf("%s[%d]", path, index))
+		}
+	}
+}
+
+// compare compares the values a and b as a whole if the given set has no children, otherwise it walks through
+// the children.
+func (w *fieldsWalker) compare(fields map[string]interface{}, a, b interface{}, path string) {
+	if !isLeaf(fields) {
+		w.walk(fields, a, b, path)
+		return
+	}
+	if jsonEqual(a, b) {
+		return
+	}
+	if w.redact {
+		w.diffs[path] = FieldDiff{Path: path, Existing: redacted(a != nil), Expected: redacted(b != nil)}
+		return
+	}
+	w.diffs[path] = FieldDiff{Path: path, Existing: format(a), Expected: format(b)}
+}
+
+// isLeaf returns true if the set has no children. The key "." marks that the item itself is owned.
+func isLeaf(fields map[string]interface{}) bool {
+	for key := range fields {
+		if key != "." {
+			return false
+		}
+	}
+	return true
+}
+
+func mapField(value interface{}, name string) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m[name]
+}
+
+func listItemByKeys(value interface{}, keys map[string]interface{}) interface{} {
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		isMatch := true
+		for key, keyValue := range keys {
+			if !jsonEqual(mapField(item, key), keyValue) {
+				isMatch = false
+				break
+			}
+		}
+		if isMatch {
+			return item
+		}
+	}
+	return nil
+}
+
+func listItemByValue(value, itemValue interface{}) interface{} {
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if jsonEqual(item, itemValue) {
+			return item
+		}
+	}
+	return nil
+}
+
+func listItemByIndex(value interface{}, index int) interface{} {
+	list, _ := value.([]interface{})
+	if index < 0 || index >= len(list) {
+		return nil
+	}
+	return list[index]
+}
+
+// jsonEqual asserts the equality of two values by their JSON representation, because the numbers of the
+// managed fields are decoded as float64, but the numbers of the objects as int64.
+func jsonEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	bytesA, errA := json.Marshal(a)
+	bytesB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(bytesA) == string(bytesB)
+}
+
+func formatKeys(keys map[string]interface{}) string {
+	parts := make([]string, 0, len(keys))
+	for key, value := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,289 @@
+package equality
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+
+	kcmk8smocks "github.com/kyma-project/kyma-companion-manager/pkg/k8s/mocks"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+const testFieldManager = "test-manager"
+
+func Test_SemanticDriftDetector(t *testing.T) {
+	t.Parallel()
+
+	detector := NewSemanticDriftDetector()
+
+	for name, tc := range deploymentEqualTestCases() {
+		tc := tc
+		t.Run("deployment: "+name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			diffs, err := detector.Diff(context.Background(), tc.getDeployment1(), tc.getDeployment2())
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, len(diffs) == 0)
+		})
+	}
+
+	for _, tc := range secretEqualTestCases() {
+		tc := tc
+		t.Run("secret: "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			diffs, err := detector.Diff(context.Background(), tc.getSecret1(), tc.getSecret2())
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, len(diffs) == 0)
+		})
+	}
+}
+
+func Test_SemanticDriftDetector_NotExisting(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var existing *kcorev1.Service
+	expected := &kcorev1.Service{ObjectMeta: kmetav1.ObjectMeta{Name: "test", Namespace: "test"}}
+
+	// when
+	diffs, err := NewSemanticDriftDetector().Diff(context.Background(), existing, expected)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []FieldDiff{{Path: objectPath, Existing: "absent", Expected: "present"}}, diffs)
+}
+
+func Test_DryRunDriftDetector_EqualityCases(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range deploymentEqualTestCases() {
+		tc := tc
+		t.Run("deployment: "+name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			existing := withAppliedFields(t, tc.getDeployment1())
+			expected := tc.getDeployment2()
+			detector := newDryRunDetectorWithResult(t, withAppliedFields(t, tc.getDeployment2()))
+
+			// when
+			diffs, err := detector.Diff(context.Background(), existing, expected)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDryRunResult, len(diffs) == 0, diffs)
+		})
+	}
+
+	for _, tc := range secretEqualTestCases() {
+		tc := tc
+		t.Run("secret: "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			existing := withAppliedFields(t, tc.getSecret1())
+			expected := tc.getSecret2()
+			detector := newDryRunDetectorWithResult(t, withAppliedFields(t, tc.getSecret2()))
+
+			// when
+			diffs, err := detector.Diff(context.Background(), existing, expected)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDryRunResult, len(diffs) == 0, diffs)
+			for _, diff := range diffs {
+				require.NotContains(t, diff.String(), "changed", "the values of secrets must be redacted")
+			}
+		})
+	}
+}
+
+func Test_DryRunDriftDetector(t *testing.T) {
+	t.Parallel()
+
+	deployment := testutils.NewCompanionDeployment("test-companion", "test-namespace")
+	deployment.Spec.Template.Spec.Containers[0].Name = "backend"
+	deployment.Spec.Template.Spec.Containers[0].LivenessProbe = &kcorev1.Probe{
+		ProbeHandler: kcorev1.ProbeHandler{
+			HTTPGet: &kcorev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8000)},
+		},
+	}
+	// the fields of the deployment which are applied by the field manager.
+	deploymentFields := `{
+		"f:metadata": {"f:labels": {"f:app.kubernetes.io/name": {}}},
+		"f:spec": {"f:template": {"f:spec": {"f:containers": {
+			"k:{\"name\":\"backend\"}": {".": {}, "f:name": {}, "f:image": {},
+				"f:livenessProbe": {"f:httpGet": {"f:path": {}, "f:port": {}}}}
+		}}}}
+	}`
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenExisting func() client.Object
+		givenResult   func() client.Object
+		givenError    error
+		wantDiffs     []FieldDiff
+		wantError     error
+	}{
+		{
+			name: "should have no diff when the dry-run does not change the managed fields",
+			givenExisting: func() client.Object {
+				return withManagedFields(deployment.DeepCopy(), deploymentFields)
+			},
+			givenResult: func() client.Object {
+				result := withManagedFields(deployment.DeepCopy(), deploymentFields)
+				// fields which are not managed by the field manager are ignored.
+				result.Spec.Template.Spec.Containers[0].Resources = kcorev1.ResourceRequirements{}
+				return result
+			},
+		},
+		{
+			name: "should report the port of the liveness probe when it drifted",
+			givenExisting: func() client.Object {
+				existing := withManagedFields(deployment.DeepCopy(), deploymentFields)
+				existing.Spec.Template.Spec.Containers[0].LivenessProbe.HTTPGet.Port = intstr.FromInt32(9000)
+				return existing
+			},
+			givenResult: func() client.Object {
+				return withManagedFields(deployment.DeepCopy(), deploymentFields)
+			},
+			wantDiffs: []FieldDiff{{
+				Path:     "spec.template.spec.containers[name=backend].livenessProbe.httpGet.port",
+				Existing: "9000",
+				Expected: "8000",
+			}},
+		},
+		{
+			name: "should report the fields which the dry-run removes",
+			givenExisting: func() client.Object {
+				return withManagedFields(deployment.DeepCopy(), deploymentFields)
+			},
+			givenResult: func() client.Object {
+				result := withManagedFields(deployment.DeepCopy(),
+					`{"f:metadata": {"f:labels": {"f:app.kubernetes.io/component": {}}}}`)
+				delete(result.Labels, "app.kubernetes.io/name")
+				return result
+			},
+			wantDiffs: []FieldDiff{{
+				Path:     "metadata.labels.app.kubernetes.io/name",
+				Existing: `"test-companion"`,
+				Expected: unsetValue,
+			}},
+		},
+		{
+			name: "should redact the values of secrets",
+			givenExisting: func() client.Object {
+				return withManagedFields(&kcorev1.Secret{Data: map[string][]byte{"key": []byte("old-value")}},
+					`{"f:data": {"f:key": {}}}`)
+			},
+			givenResult: func() client.Object {
+				return withManagedFields(&kcorev1.Secret{Data: map[string][]byte{"key": []byte("new-value")}},
+					`{"f:data": {"f:key": {}}}`)
+			},
+			wantDiffs: []FieldDiff{{Path: "data.key", Existing: redactedValue, Expected: redactedValue}},
+		},
+		{
+			name: "should return an error when the dry-run fails",
+			givenExisting: func() client.Object {
+				return deployment.DeepCopy()
+			},
+			givenError: errTest,
+			wantError:  errTest,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			kubeClient := kcmk8smocks.NewClient(t)
+			var result client.Object
+			if tc.givenResult != nil {
+				result = tc.givenResult()
+			}
+			kubeClient.On("PatchApplyDryRun", mock.Anything, mock.Anything).Return(result, tc.givenError).Once()
+			detector := NewDryRunDriftDetector(kubeClient, testFieldManager)
+
+			// when
+			diffs, err := detector.Diff(context.Background(), tc.givenExisting(), deployment.DeepCopy())
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			require.Equal(t, tc.wantDiffs, diffs)
+		})
+	}
+}
+
+func Test_DryRunDriftDetector_NotExisting(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var existing *kappsv1.Deployment
+	kubeClient := kcmk8smocks.NewClient(t)
+	detector := NewDryRunDriftDetector(kubeClient, testFieldManager)
+
+	// when
+	diffs, err := detector.Diff(context.Background(), existing,
+		testutils.NewCompanionDeployment("test-companion", "test-namespace"))
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []FieldDiff{{Path: objectPath, Existing: "absent", Expected: "present"}}, diffs)
+	kubeClient.AssertNotCalled(t, "PatchApplyDryRun", mock.Anything, mock.Anything)
+}
+
+var errTest = errors.New("test error")
+
+func newDryRunDetectorWithResult(t *testing.T, result client.Object) DriftDetector {
+	t.Helper()
+	kubeClient := kcmk8smocks.NewClient(t)
+	kubeClient.On("PatchApplyDryRun", mock.Anything, mock.Anything).Return(result, nil).Once()
+	return NewDryRunDriftDetector(kubeClient, testFieldManager)
+}
+
+// withAppliedFields sets the managed fields of the given object, as if all its fields were applied by the test
+// field manager. The schema of the object is deduced from its values, so all lists are atomic.
+func withAppliedFields[T client.Object](t *testing.T, obj T) T {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	value, err := typed.DeducedParseableType.FromUnstructured(content)
+	require.NoError(t, err)
+	fieldSet, err := value.ToFieldSet()
+	require.NoError(t, err)
+	fields, err := fieldSet.ToJSON()
+	require.NoError(t, err)
+	return withManagedFields(obj, string(fields))
+}
+
+// withManagedFields sets the given fields in the FieldsV1 format as the fields applied by the test field manager.
+func withManagedFields[T client.Object](obj T, fields string) T {
+	obj.SetManagedFields([]kmetav1.ManagedFieldsEntry{{
+		Manager:    testFieldManager,
+		Operation:  kmetav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &kmetav1.FieldsV1{Raw: []byte(fields)},
+	}})
+	return obj
+}
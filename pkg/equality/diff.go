@@ -0,0 +1,344 @@
+package equality
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// objectPath is the path of a field diff which concerns the whole object.
+	objectPath = "."
+	// redactedValue replaces the values which must not be logged, e.g. the data of secrets.
+	redactedValue = "<redacted>"
+	// unsetValue is shown for fields which are not set.
+	unsetValue = "<unset>"
+)
+
+// mapFields are the fields of maps whose keys are user-defined.
+//
+//nolint:gochecknoglobals // used as constant.
+var mapFields = map[string]bool{
+	"labels":       true,
+	"annotations":  true,
+	"matchLabels":  true,
+	"nodeSelector": true,
+	"data":         true,
+	"stringData":   true,
+	"binaryData":   true,
+}
+
+// FieldDiff is a field which differs between the existing and the expected object.
+type FieldDiff struct {
+	// Path is the path of the field, e.g. spec.template.spec.containers[0].image.
+	Path string
+	// Existing is the human-readable value of the field in the existing object.
+	Existing string
+	// Expected is the human-readable value of the field in the expected object.
+	Expected string
+}
+
+// String returns the field diff in the format "<path>: <existing> -> <expected>".
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Path, d.Existing, d.Expected)
+}
+
+// PathPrefixes returns the distinct bounded prefixes of the paths of the given field diffs, see PathPrefix.
+func PathPrefixes(diffs []FieldDiff) []string {
+	prefixes := make([]string, 0, len(diffs))
+	seen := map[string]bool{}
+	for _, diff := range diffs {
+		prefix := PathPrefix(diff.Path)
+		if seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes
+}
+
+// PathPrefix returns the prefix of the given path, which ends before the first list item, e.g.
+// spec.template.spec.containers, or after the first map with user-defined keys, e.g. metadata.labels.
+// The prefixes are bounded by the schema of the object, so they can be used as metric labels.
+func PathPrefix(path string) string {
+	if i := strings.Index(path, "["); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if mapFields[segment] {
+			return strings.Join(segments[:i+1], ".")
+		}
+	}
+	return path
+}
+
+// Strings returns the human-readable representation of the given field diffs.
+func Strings(diffs []FieldDiff) []string {
+	result := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		result = append(result, diff.String())
+	}
+	return result
+}
+
+// DeploymentDiff returns the fields which make the given deployments unequal. It returns no diffs if the
+// deployments are semantically equal, see Semantic.
+func DeploymentDiff(existing, expected *kappsv1.Deployment) []FieldDiff {
+	if existing == expected {
+		return nil
+	}
+	if existing == nil || expected == nil {
+		return []FieldDiff{objectDiff(existing, expected)}
+	}
+
+	d := &differ{}
+	d.metaDiff(existing.Name, expected.Name, existing.Namespace, expected.Namespace)
+	if !ownerReferencesDeepEqual(existing.OwnerReferences, expected.OwnerReferences) {
+		d.add("metadata.ownerReferences", existing.OwnerReferences, expected.OwnerReferences)
+	}
+	if !reflect.DeepEqual(existing.Labels, expected.Labels) {
+		d.add("metadata.labels", existing.Labels, expected.Labels)
+	}
+
+	if !replicasEqual(existing.Spec.Replicas, expected.Spec.Replicas) {
+		d.add("spec.replicas", existing.Spec.Replicas, expected.Spec.Replicas)
+	}
+	if !mapDeepEqual(selectorLabels(existing), selectorLabels(expected)) {
+		d.add("spec.selector.matchLabels", selectorLabels(existing), selectorLabels(expected))
+	}
+	if existing.Spec.MinReadySeconds != expected.Spec.MinReadySeconds {
+		d.add("spec.minReadySeconds", existing.Spec.MinReadySeconds, expected.Spec.MinReadySeconds)
+	}
+
+	t1, t2 := &existing.Spec.Template, &expected.Spec.Template
+	if !mapDeepEqual(t1.Annotations, t2.Annotations) {
+		d.add("spec.template.metadata.annotations", t1.Annotations, t2.Annotations)
+	}
+	if !mapDeepEqual(t1.Labels, t2.Labels) {
+		d.add("spec.template.metadata.labels", t1.Labels, t2.Labels)
+	}
+	d.podSpecDiff("spec.template.spec", &t1.Spec, &t2.Spec)
+	return d.diffs
+}
+
+// SecretDiff returns the fields which make the given secrets unequal. It returns no diffs if the secrets are
+// semantically equal, see Semantic. The values of the secret data are redacted, only the changed keys are
+// reported.
+func SecretDiff(existing, expected *kcorev1.Secret) []FieldDiff {
+	if existing == expected {
+		return nil
+	}
+	if existing == nil || expected == nil {
+		return []FieldDiff{objectDiff(existing, expected)}
+	}
+
+	d := &differ{}
+	d.metaDiff(existing.Name, expected.Name, existing.Namespace, expected.Namespace)
+	if !ownerReferencesDeepEqual(existing.OwnerReferences, expected.OwnerReferences) {
+		d.add("metadata.ownerReferences", existing.OwnerReferences, expected.OwnerReferences)
+	}
+	if !reflect.DeepEqual(existing.Labels, expected.Labels) {
+		d.add("metadata.labels", existing.Labels, expected.Labels)
+	}
+	if existing.Type != expected.Type {
+		d.add("type", existing.Type, expected.Type)
+	}
+
+	// report the changed keys in a stable order, without their values.
+	for _, key := range sortedKeys(existing.Data, expected.Data) {
+		v1, ok1 := existing.Data[key]
+		v2, ok2 := expected.Data[key]
+		if ok1 == ok2 && reflect.DeepEqual(v1, v2) {
+			continue
+		}
+		d.diffs = append(d.diffs, FieldDiff{
+			Path:     fmt.Sprintf("data[%s]", key),
+			Existing: redacted(ok1),
+			Expected: redacted(ok2),
+		})
+	}
+	// nil and empty data are unequal.
+	if len(existing.Data) == 0 && len(expected.Data) == 0 && !reflect.DeepEqual(existing.Data, expected.Data) {
+		d.add("data", existing.Data, expected.Data)
+	}
+	return d.diffs
+}
+
+// differ collects the field diffs of two objects.
+type differ struct {
+	diffs []FieldDiff
+}
+
+// add adds a field diff with the human-readable values of the given fields.
+func (d *differ) add(path string, existing, expected interface{}) {
+	d.diffs = append(d.diffs, FieldDiff{Path: path, Existing: format(existing), Expected: format(expected)})
+}
+
+func (d *differ) metaDiff(name1, name2, namespace1, namespace2 string) {
+	if name1 != name2 {
+		d.add("metadata.name", name1, name2)
+	}
+	if namespace1 != namespace2 {
+		d.add("metadata.namespace", namespace1, namespace2)
+	}
+}
+
+// podSpecDiff adds the fields which differ between the given pod specs.
+func (d *differ) podSpecDiff(path string, ps1, ps2 *kcorev1.PodSpec) {
+	if len(ps1.Containers) != len(ps2.Containers) {
+		d.add(path+".containers", containerNames(ps1.Containers), containerNames(ps2.Containers))
+	} else {
+		for i := range ps1.Containers {
+			d.containerDiff(fmt.Sprintf("%s.containers[%d]", path, i), &ps1.Containers[i], &ps2.Containers[i])
+		}
+	}
+
+	if !volumesEqual(ps1.Volumes, ps2.Volumes) {
+		d.add(path+".volumes", ps1.Volumes, ps2.Volumes)
+	}
+	if !podSecurityContextEqual(ps1.SecurityContext, ps2.SecurityContext) {
+		d.add(path+".securityContext", ps1.SecurityContext, ps2.SecurityContext)
+	}
+
+	// compare the scheduling fields.
+	if !mapDeepEqual(ps1.NodeSelector, ps2.NodeSelector) {
+		d.add(path+".nodeSelector", ps1.NodeSelector, ps2.NodeSelector)
+	}
+	if !reflect.DeepEqual(ps1.Affinity, ps2.Affinity) {
+		d.add(path+".affinity", ps1.Affinity, ps2.Affinity)
+	}
+	if (len(ps1.Tolerations) != 0 || len(ps2.Tolerations) != 0) &&
+		!reflect.DeepEqual(ps1.Tolerations, ps2.Tolerations) {
+		d.add(path+".tolerations", ps1.Tolerations, ps2.Tolerations)
+	}
+	if (len(ps1.TopologySpreadConstraints) != 0 || len(ps2.TopologySpreadConstraints) != 0) &&
+		!reflect.DeepEqual(ps1.TopologySpreadConstraints, ps2.TopologySpreadConstraints) {
+		d.add(path+".topologySpreadConstraints", ps1.TopologySpreadConstraints, ps2.TopologySpreadConstraints)
+	}
+
+	if ps1.ServiceAccountName != ps2.ServiceAccountName {
+		d.add(path+".serviceAccountName", ps1.ServiceAccountName, ps2.ServiceAccountName)
+	}
+}
+
+// containerDiff adds the fields which differ between the given containers. The values of the environment
+// variables are redacted, only their names are reported.
+func (d *differ) containerDiff(path string, c1, c2 *kcorev1.Container) {
+	if c1.Image != c2.Image {
+		d.add(path+".image", c1.Image, c2.Image)
+	}
+	if c1.ImagePullPolicy != c2.ImagePullPolicy {
+		d.add(path+".imagePullPolicy", c1.ImagePullPolicy, c2.ImagePullPolicy)
+	}
+	if !portsEqual(c1.Ports, c2.Ports) {
+		d.add(path+".ports", c1.Ports, c2.Ports)
+	}
+	if !envEqual(c1.Env, c2.Env) {
+		d.add(path+".env", envNames(c1.Env), envNames(c2.Env))
+	}
+	if !reflect.DeepEqual(c1.Resources, c2.Resources) {
+		d.add(path+".resources", c1.Resources, c2.Resources)
+	}
+	if !volumeMountsEqual(c1.VolumeMounts, c2.VolumeMounts) {
+		d.add(path+".volumeMounts", c1.VolumeMounts, c2.VolumeMounts)
+	}
+	if !securityContextEqual(c1.SecurityContext, c2.SecurityContext) {
+		d.add(path+".securityContext", c1.SecurityContext, c2.SecurityContext)
+	}
+	if !probeEqual(c1.ReadinessProbe, c2.ReadinessProbe) {
+		d.add(path+".readinessProbe", c1.ReadinessProbe, c2.ReadinessProbe)
+	}
+}
+
+// format returns the given value as compact JSON, or unsetValue if the value is nil.
+func format(value interface{}) string {
+	if value == nil {
+		return unsetValue
+	}
+	if rv := reflect.ValueOf(value); (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Map ||
+		rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return unsetValue
+	}
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+func redacted(isSet bool) string {
+	if isSet {
+		return redactedValue
+	}
+	return unsetValue
+}
+
+// objectDiff returns the diff of two objects of which at least one does not exist.
+func objectDiff(existing, expected interface{}) FieldDiff {
+	return FieldDiff{Path: objectPath, Existing: objectState(existing), Expected: objectState(expected)}
+}
+
+func objectState(obj interface{}) string {
+	if isNil(obj) {
+		return "absent"
+	}
+	return "present"
+}
+
+func isNil(obj interface{}) bool {
+	if obj == nil {
+		return true
+	}
+	value := reflect.ValueOf(obj)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+func selectorLabels(deployment *kappsv1.Deployment) map[string]string {
+	if deployment.Spec.Selector == nil {
+		return nil
+	}
+	return deployment.Spec.Selector.MatchLabels
+}
+
+func sortedKeys(m1, m2 map[string][]byte) []string {
+	keys := make([]string, 0, len(m1)+len(m2))
+	for key := range m1 {
+		keys = append(keys, key)
+	}
+	for key := range m2 {
+		if _, ok := m1[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containerNames(containers []kcorev1.Container) []string {
+	names := make([]string, 0, len(containers))
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	return names
+}
+
+func envNames(env []kcorev1.EnvVar) []string {
+	if env == nil {
+		return nil
+	}
+	names := make([]string, 0, len(env))
+	for _, ev := range env {
+		names = append(names, ev.Name)
+	}
+	return names
+}
@@ -0,0 +1,232 @@
+package equality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+func Test_DeploymentDiff(t *testing.T) {
+	t.Parallel()
+
+	defaultDeployment := testutils.NewCompanionDeployment("test-companion", "test-namespace")
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenModifier func(deployment *kappsv1.Deployment)
+		wantDiffs     []FieldDiff
+	}{
+		{
+			name:          "should have no diff when the deployments are equal",
+			givenModifier: func(_ *kappsv1.Deployment) {},
+		},
+		{
+			name: "should have no diff when the replicas are not managed",
+			givenModifier: func(deployment *kappsv1.Deployment) {
+				deployment.Spec.Replicas = nil
+			},
+		},
+		{
+			name: "should report the image and the replicas when they drifted",
+			givenModifier: func(deployment *kappsv1.Deployment) {
+				deployment.Spec.Replicas = ptr.To(int32(5))
+				deployment.Spec.Template.Spec.Containers[0].Image = "drifted-image"
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "spec.replicas", Existing: "5", Expected: "1"},
+				{
+					Path:     "spec.template.spec.containers[0].image",
+					Existing: `"drifted-image"`,
+					Expected: `"` + defaultDeployment.Spec.Template.Spec.Containers[0].Image + `"`,
+				},
+			},
+		},
+		{
+			name: "should report the pod annotations when they drifted",
+			givenModifier: func(deployment *kappsv1.Deployment) {
+				deployment.Spec.Template.Annotations = map[string]string{"key": "value"}
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "spec.template.metadata.annotations", Existing: `{"key":"value"}`, Expected: unsetValue},
+			},
+		},
+		{
+			name: "should report the names of the environment variables without their values",
+			givenModifier: func(deployment *kappsv1.Deployment) {
+				deployment.Spec.Template.Spec.Containers[0].Env = []kcorev1.EnvVar{{Name: "key", Value: "secret"}}
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "spec.template.spec.containers[0].env", Existing: `["key"]`, Expected: unsetValue},
+			},
+		},
+		{
+			name: "should report the service account name when it drifted",
+			givenModifier: func(deployment *kappsv1.Deployment) {
+				deployment.Spec.Template.Spec.ServiceAccountName = "other"
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "spec.template.spec.serviceAccountName", Existing: `"other"`, Expected: `""`},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			expected := defaultDeployment.DeepCopy()
+			expected.Spec.Replicas = ptr.To(int32(1))
+			existing := expected.DeepCopy()
+			tc.givenModifier(existing)
+
+			// when
+			diffs := DeploymentDiff(existing, expected)
+
+			// then
+			require.Equal(t, tc.wantDiffs, diffs)
+		})
+	}
+}
+
+func Test_SecretDiff(t *testing.T) {
+	t.Parallel()
+
+	defaultSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "testNamespace",
+		},
+		Data: map[string][]byte{
+			"hana-db-secret": []byte("hana-db-secret"),
+			"redis-secret":   []byte("redis-secret"),
+		},
+		Type: kcorev1.SecretTypeOpaque,
+	}
+
+	// define test cases
+	testCases := []struct {
+		name          string
+		givenModifier func(secret *kcorev1.Secret)
+		wantDiffs     []FieldDiff
+	}{
+		{
+			name:          "should have no diff when the secrets are equal",
+			givenModifier: func(_ *kcorev1.Secret) {},
+		},
+		{
+			name: "should report the changed, added and removed keys with redacted values",
+			givenModifier: func(secret *kcorev1.Secret) {
+				secret.Data = map[string][]byte{
+					"hana-db-secret": []byte("changed"),
+					"other-secret":   []byte("other-secret"),
+				}
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "data[hana-db-secret]", Existing: redactedValue, Expected: redactedValue},
+				{Path: "data[other-secret]", Existing: redactedValue, Expected: unsetValue},
+				{Path: "data[redis-secret]", Existing: unsetValue, Expected: redactedValue},
+			},
+		},
+		{
+			name: "should report the type and the labels when they drifted",
+			givenModifier: func(secret *kcorev1.Secret) {
+				secret.Type = kcorev1.SecretTypeBasicAuth
+				secret.Labels = map[string]string{"key": "value"}
+			},
+			wantDiffs: []FieldDiff{
+				{Path: "metadata.labels", Existing: `{"key":"value"}`, Expected: unsetValue},
+				{Path: "type", Existing: `"kubernetes.io/basic-auth"`, Expected: `"Opaque"`},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			existing := defaultSecret.DeepCopy()
+			tc.givenModifier(existing)
+
+			// when
+			diffs := SecretDiff(existing, defaultSecret)
+
+			// then
+			require.Equal(t, tc.wantDiffs, diffs)
+			for _, diff := range diffs {
+				require.NotContains(t, diff.String(), "changed")
+			}
+		})
+	}
+}
+
+func Test_Diff_NotExisting(t *testing.T) {
+	t.Parallel()
+
+	// given
+	deployment := testutils.NewCompanionDeployment("test-companion", "test-namespace")
+	secret := &kcorev1.Secret{ObjectMeta: kmetav1.ObjectMeta{Name: "test", Namespace: "testNamespace"}}
+
+	// when
+	deploymentDiffs := DeploymentDiff(nil, deployment)
+	secretDiffs := SecretDiff(secret, nil)
+
+	// then
+	require.Equal(t, []FieldDiff{{Path: objectPath, Existing: "absent", Expected: "present"}}, deploymentDiffs)
+	require.Equal(t, []FieldDiff{{Path: objectPath, Existing: "present", Expected: "absent"}}, secretDiffs)
+}
+
+func Test_PathPrefixes(t *testing.T) {
+	t.Parallel()
+
+	// given
+	diffs := []FieldDiff{
+		{Path: objectPath},
+		{Path: "spec.replicas"},
+		{Path: "spec.template.spec.containers[0].image"},
+		{Path: "spec.template.spec.containers[name=backend].env[name=KEY].value"},
+		{Path: "metadata.labels.app.kubernetes.io/name"},
+		{Path: "metadata.labels"},
+		{Path: "spec.template.spec.nodeSelector.topology.kubernetes.io/zone"},
+		{Path: "data[hana-db-secret]"},
+		{Path: "data.redis-secret"},
+	}
+
+	// when
+	prefixes := PathPrefixes(diffs)
+
+	// then
+	require.Equal(t, []string{
+		objectPath,
+		"spec.replicas",
+		"spec.template.spec.containers",
+		"metadata.labels",
+		"spec.template.spec.nodeSelector",
+		"data",
+	}, prefixes)
+}
+
+func Test_FieldDiff_String(t *testing.T) {
+	t.Parallel()
+
+	// given
+	diff := FieldDiff{Path: "spec.replicas", Existing: "5", Expected: "1"}
+
+	// when
+	result := diff.String()
+
+	// then
+	require.Equal(t, "spec.replicas: 5 -> 1", result)
+}
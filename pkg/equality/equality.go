@@ -4,9 +4,15 @@ import (
 	"reflect"
 
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/utils/ptr"
 )
 
 // Semantic can do semantic deep equality checks for API objects. Fields which
@@ -17,6 +23,13 @@ var Semantic = conversion.EqualitiesOrDie(
 	deploymentEqual,
 	serviceEqual,
 	secretEqual,
+	hpaEqual,
+	pdbEqual,
+	networkPolicyEqual,
+	serviceAccountEqual,
+	clusterRoleEqual,
+	clusterRoleBindingEqual,
+	unstructuredEqual,
 )
 
 func serviceEqual(a, b *kcorev1.Service) bool {
@@ -38,32 +51,9 @@ func serviceEqual(a, b *kcorev1.Service) bool {
 	return reflect.DeepEqual(a.Spec.Ports, b.Spec.Ports)
 }
 
+// secretEqual asserts the equality of two Secret objects, i.e. that SecretDiff reports no diffs.
 func secretEqual(a, b *kcorev1.Secret) bool {
-	if a == b {
-		return true
-	}
-
-	if a == nil || b == nil {
-		return false
-	}
-
-	if !reflect.DeepEqual(a.Labels, b.Labels) {
-		return false
-	}
-
-	if !reflect.DeepEqual(a.Type, b.Type) {
-		return false
-	}
-
-	if !ownerReferencesDeepEqual(a.OwnerReferences, b.OwnerReferences) {
-		return false
-	}
-
-	if a.Name != b.Name || a.Namespace != b.Namespace {
-		return false
-	}
-
-	return reflect.DeepEqual(a.Data, b.Data)
+	return len(SecretDiff(a, b)) == 0
 }
 
 func ownerReferencesDeepEqual(ors1, ors2 []kmetav1.OwnerReference) bool {
@@ -88,51 +78,153 @@ func ownerReferencesDeepEqual(ors1, ors2 []kmetav1.OwnerReference) bool {
 	return true
 }
 
-// deploymentEqual asserts the equality of two Deployment objects.
+// deploymentEqual asserts the equality of two Deployment objects, i.e. that DeploymentDiff reports no diffs.
 func deploymentEqual(a, b *kappsv1.Deployment) bool {
+	return len(DeploymentDiff(a, b)) == 0
+}
+
+// replicasEqual asserts the equality of two replica counts. If one of them is not set, the replicas are
+// considered as not managed (e.g. they are managed by a HorizontalPodAutoscaler) and it returns true.
+func replicasEqual(a, b *int32) bool {
 	if a == nil || b == nil {
-		return false
+		return true
 	}
+	return *a == *b
+}
+
+// hpaEqual asserts the equality of two HorizontalPodAutoscaler objects.
+func hpaEqual(a, b *kautoscalingv2.HorizontalPodAutoscaler) bool {
 	if a == b {
 		return true
 	}
-
-	// compare objectMeta.
-	objectMeta1 := a.ObjectMeta.DeepCopy()
-	objectMeta2 := b.ObjectMeta.DeepCopy()
-	if objectMeta1.Name != objectMeta2.Name ||
-		objectMeta1.Namespace != objectMeta2.Namespace {
+	if a == nil || b == nil {
+		return false
+	}
+	if a.Name != b.Name || a.Namespace != b.Namespace {
+		return false
+	}
+	if !ownerReferencesDeepEqual(a.OwnerReferences, b.OwnerReferences) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Labels, b.Labels) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Spec.ScaleTargetRef, b.Spec.ScaleTargetRef) ||
+		a.Spec.MaxReplicas != b.Spec.MaxReplicas {
+		return false
+	}
+	// the minReplicas are defaulted to 1 by the API server.
+	if ptr.Deref(a.Spec.MinReplicas, 1) != ptr.Deref(b.Spec.MinReplicas, 1) {
 		return false
 	}
+	return reflect.DeepEqual(a.Spec.Metrics, b.Spec.Metrics)
+}
 
-	if !ownerReferencesDeepEqual(objectMeta1.OwnerReferences, objectMeta2.OwnerReferences) {
+// pdbEqual asserts the equality of two PodDisruptionBudget objects.
+func pdbEqual(a, b *kpolicyv1.PodDisruptionBudget) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if a.Name != b.Name || a.Namespace != b.Namespace {
+		return false
+	}
+	if !ownerReferencesDeepEqual(a.OwnerReferences, b.OwnerReferences) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Labels, b.Labels) {
 		return false
 	}
+	if !reflect.DeepEqual(a.Spec.Selector, b.Spec.Selector) {
+		return false
+	}
+	return reflect.DeepEqual(a.Spec.MinAvailable, b.Spec.MinAvailable) &&
+		reflect.DeepEqual(a.Spec.MaxUnavailable, b.Spec.MaxUnavailable)
+}
 
+// networkPolicyEqual asserts the equality of two NetworkPolicy objects. Empty and nil rules are equal,
+// because empty rules are omitted by the API server.
+func networkPolicyEqual(a, b *knetworkingv1.NetworkPolicy) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if a.Name != b.Name || a.Namespace != b.Namespace {
+		return false
+	}
+	if !ownerReferencesDeepEqual(a.OwnerReferences, b.OwnerReferences) {
+		return false
+	}
 	if !reflect.DeepEqual(a.Labels, b.Labels) {
 		return false
 	}
+	if !mapDeepEqual(a.Spec.PodSelector.MatchLabels, b.Spec.PodSelector.MatchLabels) ||
+		!reflect.DeepEqual(a.Spec.PodSelector.MatchExpressions, b.Spec.PodSelector.MatchExpressions) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Spec.PolicyTypes, b.Spec.PolicyTypes) {
+		return false
+	}
+	if (len(a.Spec.Ingress) != 0 || len(b.Spec.Ingress) != 0) && !reflect.DeepEqual(a.Spec.Ingress, b.Spec.Ingress) {
+		return false
+	}
+	return (len(a.Spec.Egress) == 0 && len(b.Spec.Egress) == 0) || reflect.DeepEqual(a.Spec.Egress, b.Spec.Egress)
+}
 
-	// compare spec
-	if !reflect.DeepEqual(a.Spec.Replicas, b.Spec.Replicas) ||
-		!mapDeepEqual(a.Spec.Selector.MatchLabels, b.Spec.Selector.MatchLabels) ||
-		a.Spec.MinReadySeconds != b.Spec.MinReadySeconds {
+// serviceAccountEqual asserts the equality of two ServiceAccount objects. The secrets of the
+// ServiceAccounts are ignored, as they are managed by Kubernetes.
+func serviceAccountEqual(a, b *kcorev1.ServiceAccount) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
 		return false
 	}
+	if a.Name != b.Name || a.Namespace != b.Namespace {
+		return false
+	}
+	if !ownerReferencesDeepEqual(a.OwnerReferences, b.OwnerReferences) {
+		return false
+	}
+	return reflect.DeepEqual(a.Labels, b.Labels)
+}
 
-	// compare pod template.
-	cst1 := a.Spec.Template
-	cst2 := b.Spec.Template
-	if !mapDeepEqual(cst1.Annotations, cst2.Annotations) {
+// clusterRoleEqual asserts the equality of two ClusterRole objects.
+func clusterRoleEqual(a, b *krbacv1.ClusterRole) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
 		return false
 	}
-	if !mapDeepEqual(cst1.Labels, cst2.Labels) {
+	if a.Name != b.Name {
 		return false
 	}
+	if !reflect.DeepEqual(a.Labels, b.Labels) {
+		return false
+	}
+	return reflect.DeepEqual(a.Rules, b.Rules) && reflect.DeepEqual(a.AggregationRule, b.AggregationRule)
+}
 
-	ps1 := &cst1.Spec
-	ps2 := &cst2.Spec
-	return podSpecEqual(ps1, ps2)
+// clusterRoleBindingEqual asserts the equality of two ClusterRoleBinding objects.
+func clusterRoleBindingEqual(a, b *krbacv1.ClusterRoleBinding) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if a.Name != b.Name {
+		return false
+	}
+	if !reflect.DeepEqual(a.Labels, b.Labels) {
+		return false
+	}
+	return reflect.DeepEqual(a.RoleRef, b.RoleRef) && reflect.DeepEqual(a.Subjects, b.Subjects)
 }
 
 // mapDeepEqual returns true if two non-empty maps are equal, otherwise returns false.
@@ -145,31 +237,28 @@ func mapDeepEqual(m1, m2 map[string]string) bool {
 	return reflect.DeepEqual(m1, m2)
 }
 
-// podSpecEqual asserts the equality of two PodSpec objects.
-func podSpecEqual(ps1, ps2 *kcorev1.PodSpec) bool {
-	if ps1 == nil || ps2 == nil {
-		return false
+// podSecurityContextEqual asserts the equality of two PodSecurityContext objects. A nil security context
+// is equal to an empty one, because the API server defaults it to an empty object.
+func podSecurityContextEqual(a, b *kcorev1.PodSecurityContext) bool {
+	if a == nil {
+		a = &kcorev1.PodSecurityContext{}
 	}
-	if ps1 == ps2 {
-		return true
+	if b == nil {
+		b = &kcorev1.PodSecurityContext{}
 	}
+	return reflect.DeepEqual(a, b)
+}
 
-	cs1, cs2 := ps1.Containers, ps2.Containers
-	if len(cs1) != len(cs2) {
-		return false
+// securityContextEqual asserts the equality of two container SecurityContext objects. A nil security
+// context is equal to an empty one.
+func securityContextEqual(a, b *kcorev1.SecurityContext) bool {
+	if a == nil {
+		a = &kcorev1.SecurityContext{}
 	}
-	for i := range cs1 {
-		if !containerEqual(&cs1[i], &cs2[i]) {
-			return false
-		}
-	}
-
-	// compare volumeMounts.
-	if !volumesEqual(ps1.Volumes, ps2.Volumes) {
-		return false
+	if b == nil {
+		b = &kcorev1.SecurityContext{}
 	}
-
-	return ps1.ServiceAccountName == ps2.ServiceAccountName
+	return reflect.DeepEqual(a, b)
 }
 
 func volumesEqual(a, b []kcorev1.Volume) bool {
@@ -198,16 +287,18 @@ func volumeMountsEqual(a, b []kcorev1.VolumeMount) bool {
 	return true
 }
 
-// containerEqual asserts the equality of two Container objects.
+// containerEqual asserts the equality of two Container objects, i.e. that containerDiff reports no diffs.
 func containerEqual(a, b *kcorev1.Container) bool {
 	if a == nil || b == nil {
 		return false
 	}
-	if a.Image != b.Image {
-		return false
-	}
+	d := &differ{}
+	d.containerDiff("", a, b)
+	return len(d.diffs) == 0
+}
 
-	ps1, ps2 := a.Ports, b.Ports
+// portsEqual asserts the equality of two container port slices, regardless of their order.
+func portsEqual(ps1, ps2 []kcorev1.ContainerPort) bool {
 	if len(ps1) != len(ps2) {
 		return false
 	}
@@ -226,24 +317,10 @@ func containerEqual(a, b *kcorev1.Container) bool {
 			return isFound
 		}
 	}
-
-	if !envEqual(a.Env, b.Env) {
-		return false
-	}
-
-	if !reflect.DeepEqual(a.Resources, b.Resources) {
-		return false
-	}
-
-	if !volumeMountsEqual(a.VolumeMounts, b.VolumeMounts) {
-		return false
-	}
-
-	return probeEqual(a.ReadinessProbe, b.ReadinessProbe)
+	return true
 }
 
-// envEqual asserts the equality of two core environment slices. It's used
-// by containerEqual.
+// envEqual asserts the equality of two core environment slices.
 func envEqual(a, b []kcorev1.EnvVar) bool {
 	if len(a) != len(b) {
 		return false
@@ -268,8 +345,7 @@ func envEqual(a, b []kcorev1.EnvVar) bool {
 	return isFound
 }
 
-// probeEqual asserts the equality of two Probe objects. It's used by
-// containerEqual.
+// probeEqual asserts the equality of two Probe objects.
 func probeEqual(a, b *kcorev1.Probe) bool {
 	if a == b {
 		return true
@@ -330,3 +406,27 @@ func realProto(pr kcorev1.Protocol) kcorev1.Protocol {
 	}
 	return pr
 }
+
+// unstructuredEqual compares the custom resources which are handled as unstructured objects, e.g. the APIRule.
+// Only the metadata managed by the Kyma companion manager and the spec are compared.
+func unstructuredEqual(a, b *kunstructured.Unstructured) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if a.GroupVersionKind() != b.GroupVersionKind() {
+		return false
+	}
+	if a.GetName() != b.GetName() || a.GetNamespace() != b.GetNamespace() {
+		return false
+	}
+	if !reflect.DeepEqual(a.GetLabels(), b.GetLabels()) {
+		return false
+	}
+	if !ownerReferencesDeepEqual(a.GetOwnerReferences(), b.GetOwnerReferences()) {
+		return false
+	}
+	return reflect.DeepEqual(a.Object["spec"], b.Object["spec"])
+}
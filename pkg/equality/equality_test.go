@@ -6,18 +6,40 @@ import (
 
 	"github.com/stretchr/testify/require"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	kcmk8sapirule "github.com/kyma-project/kyma-companion-manager/pkg/k8s/apirule"
+	kcmk8shpa "github.com/kyma-project/kyma-companion-manager/pkg/k8s/hpa"
+	kcmk8snetworkpolicy "github.com/kyma-project/kyma-companion-manager/pkg/k8s/networkpolicy"
+	kcmk8spdb "github.com/kyma-project/kyma-companion-manager/pkg/k8s/pdb"
+	kcmk8srbac "github.com/kyma-project/kyma-companion-manager/pkg/k8s/rbac"
+	kcmk8sserviceaccount "github.com/kyma-project/kyma-companion-manager/pkg/k8s/serviceaccount"
 	"github.com/kyma-project/kyma-companion-manager/pkg/utils"
 	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
 )
 
-func Test_deploymentEqual(t *testing.T) {
+// deploymentEqualTestCase is a test case of the equality of two deployments. The test cases are shared by the
+// tests of deploymentEqual and of the drift detectors.
+type deploymentEqualTestCase struct {
+	getDeployment1 func() *kappsv1.Deployment
+	getDeployment2 func() *kappsv1.Deployment
+	expectedResult bool
+	// expectedDryRunResult is the expected equality reported by the dry-run drift detector, whose simulated
+	// dry-run neither defaults the objects like the API server, nor knows other field managers.
+	expectedDryRunResult bool
+}
+
+func deploymentEqualTestCases() map[string]deploymentEqualTestCase {
 	defaultDeployment := testutils.NewCompanionDeployment("test-companion", "test-namespace")
 	ownerReference := func(version, kind, name, uid string, controller, block *bool) kmetav1.OwnerReference {
 		return kmetav1.OwnerReference{
@@ -30,11 +52,7 @@ func Test_deploymentEqual(t *testing.T) {
 		}
 	}
 
-	testCases := map[string]struct {
-		getDeployment1 func() *kappsv1.Deployment
-		getDeployment2 func() *kappsv1.Deployment
-		expectedResult bool
-	}{
+	return map[string]deploymentEqualTestCase{
 		"should be equal if same default deployments": {
 			getDeployment1: func() *kappsv1.Deployment {
 				p := defaultDeployment.DeepCopy()
@@ -44,7 +62,8 @@ func Test_deploymentEqual(t *testing.T) {
 				p := defaultDeployment.DeepCopy()
 				return p
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should be unequal if container image changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -55,7 +74,20 @@ func Test_deploymentEqual(t *testing.T) {
 			getDeployment2: func() *kappsv1.Deployment {
 				return defaultDeployment.DeepCopy()
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be unequal if image pull policy changes": {
+			getDeployment1: func() *kappsv1.Deployment {
+				p := defaultDeployment.DeepCopy()
+				p.Spec.Template.Spec.Containers[0].ImagePullPolicy = kcorev1.PullIfNotPresent
+				return p
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				return defaultDeployment.DeepCopy()
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be unequal if env var changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -78,7 +110,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if env var are same": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -101,7 +134,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should not be equal if replicas changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -116,7 +150,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Replicas = &replicas
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if replicas are the same": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -131,7 +166,25 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Replicas = &replicas
 				return deploy
 			},
+			expectedResult:       true,
+			expectedDryRunResult: true,
+		},
+		"should be equal if replicas are not set in one of them": {
+			getDeployment1: func() *kappsv1.Deployment {
+				replicas := int32(2)
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Replicas = &replicas
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Replicas = nil
+				return deploy
+			},
 			expectedResult: true,
+			// the replicas which are not applied by the manager are owned by the HorizontalPodAutoscaler, which the
+			// simulated dry-run of the tests does not know.
+			expectedDryRunResult: false,
 		},
 		"should be equal if spec annotations are nil and empty": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -144,7 +197,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Template.Annotations = map[string]string{}
 				return deploy
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should be unequal if spec annotations changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -157,7 +211,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Template.Annotations = map[string]string{"key": "value2"}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if spec Labels are nil and empty": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -170,7 +225,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Template.Labels = map[string]string{}
 				return deploy
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should be unequal if spec Labels changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -183,7 +239,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Spec.Template.Labels = map[string]string{"key": "value2"}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be unequal if Labels changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -196,7 +253,8 @@ func Test_deploymentEqual(t *testing.T) {
 				deploy.Labels = map[string]string{"key": "value2"}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be unequal if owner reference changes": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -213,7 +271,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if owner references are same": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -230,7 +289,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should be unequal if volumes are different": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -263,7 +323,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if volumes are same": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -296,7 +357,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		"should be unequal if volumeMounts are different": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -321,7 +383,8 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		"should be equal if volumeMounts are same": {
 			getDeployment1: func() *kappsv1.Deployment {
@@ -346,10 +409,174 @@ func Test_deploymentEqual(t *testing.T) {
 				}
 				return deploy
 			},
+			expectedResult:       true,
+			expectedDryRunResult: true,
+		},
+		"should be unequal if pod security contexts are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.SecurityContext = &kcorev1.PodSecurityContext{
+					RunAsNonRoot: ptr.To(true),
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.SecurityContext = &kcorev1.PodSecurityContext{
+					RunAsNonRoot: ptr.To(false),
+				}
+				return deploy
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be equal if pod security contexts are nil and empty": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.SecurityContext = nil
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.SecurityContext = &kcorev1.PodSecurityContext{}
+				return deploy
+			},
 			expectedResult: true,
+			// the API server defaults the security context of pods to an empty one, which the simulated dry-run of
+			// the tests does not.
+			expectedDryRunResult: false,
+		},
+		"should be unequal if container security contexts are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Containers[0].SecurityContext = &kcorev1.SecurityContext{
+					ReadOnlyRootFilesystem: ptr.To(true),
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Containers[0].SecurityContext = &kcorev1.SecurityContext{
+					ReadOnlyRootFilesystem: ptr.To(false),
+				}
+				return deploy
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be equal if container security contexts are same": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Containers[0].SecurityContext = &kcorev1.SecurityContext{
+					ReadOnlyRootFilesystem: ptr.To(true),
+					Capabilities:           &kcorev1.Capabilities{Drop: []kcorev1.Capability{"ALL"}},
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Containers[0].SecurityContext = &kcorev1.SecurityContext{
+					ReadOnlyRootFilesystem: ptr.To(true),
+					Capabilities:           &kcorev1.Capabilities{Drop: []kcorev1.Capability{"ALL"}},
+				}
+				return deploy
+			},
+			expectedResult:       true,
+			expectedDryRunResult: true,
+		},
+		"should be unequal if tolerations are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Tolerations = []kcorev1.Toleration{
+					{Key: "dedicated", Operator: kcorev1.TolerationOpExists},
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				return defaultDeployment.DeepCopy()
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be equal if tolerations are nil and empty": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Tolerations = nil
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Tolerations = []kcorev1.Toleration{}
+				return deploy
+			},
+			expectedResult:       true,
+			expectedDryRunResult: true,
+		},
+		"should be unequal if affinities are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.Affinity = &kcorev1.Affinity{
+					PodAntiAffinity: &kcorev1.PodAntiAffinity{},
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				return defaultDeployment.DeepCopy()
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be unequal if topology spread constraints are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.TopologySpreadConstraints = []kcorev1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: kcorev1.ScheduleAnyway},
+				}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.TopologySpreadConstraints = []kcorev1.TopologySpreadConstraint{
+					{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: kcorev1.ScheduleAnyway},
+				}
+				return deploy
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be unequal if node selectors are different": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "a"}
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "b"}
+				return deploy
+			},
+			expectedResult:       false,
+			expectedDryRunResult: false,
+		},
+		"should be equal if node selectors are nil and empty": {
+			getDeployment1: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.NodeSelector = nil
+				return deploy
+			},
+			getDeployment2: func() *kappsv1.Deployment {
+				deploy := defaultDeployment.DeepCopy()
+				deploy.Spec.Template.Spec.NodeSelector = map[string]string{}
+				return deploy
+			},
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 	}
-	for name, tc := range testCases {
+}
+
+func Test_deploymentEqual(t *testing.T) {
+	for name, tc := range deploymentEqualTestCases() {
 		t.Run(name, func(t *testing.T) {
 			if deploymentEqual(tc.getDeployment1(), tc.getDeployment2()) != tc.expectedResult {
 				t.Errorf("expected output to be %t", tc.expectedResult)
@@ -1160,7 +1387,18 @@ func Test_probeEqual(t *testing.T) {
 	}
 }
 
-func Test_secretEqual(t *testing.T) {
+// secretEqualTestCase is a test case of the equality of two secrets. The test cases are shared by the tests of
+// secretEqual and of the drift detectors.
+type secretEqualTestCase struct {
+	name           string
+	getSecret1     func() *kcorev1.Secret
+	getSecret2     func() *kcorev1.Secret
+	expectedResult bool
+	// expectedDryRunResult is the expected equality reported by the dry-run drift detector.
+	expectedDryRunResult bool
+}
+
+func secretEqualTestCases() []secretEqualTestCase {
 	defaultSecret := &kcorev1.Secret{
 		TypeMeta: kmetav1.TypeMeta{
 			Kind:       "Secret",
@@ -1190,12 +1428,7 @@ func Test_secretEqual(t *testing.T) {
 		}
 	}
 
-	tests := []struct {
-		name           string
-		getSecret1     func() *kcorev1.Secret
-		getSecret2     func() *kcorev1.Secret
-		expectedResult bool
-	}{
+	return []secretEqualTestCase{
 		{
 			name: "should be equal when secrets are equal",
 			getSecret1: func() *kcorev1.Secret {
@@ -1204,7 +1437,8 @@ func Test_secretEqual(t *testing.T) {
 			getSecret2: func() *kcorev1.Secret {
 				return defaultSecret.DeepCopy()
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		{
 			name: "should be unequal when owner references are different",
@@ -1222,7 +1456,8 @@ func Test_secretEqual(t *testing.T) {
 				}
 				return secret
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		{
 			name: "should be equal when owner references are same",
@@ -1240,7 +1475,8 @@ func Test_secretEqual(t *testing.T) {
 				}
 				return secret
 			},
-			expectedResult: true,
+			expectedResult:       true,
+			expectedDryRunResult: true,
 		},
 		{
 			name: "should be unequal when data is different",
@@ -1264,7 +1500,8 @@ func Test_secretEqual(t *testing.T) {
 				}
 				return secret
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		{
 			name: "should be unequal when name is different",
@@ -1278,7 +1515,8 @@ func Test_secretEqual(t *testing.T) {
 				secret.Name = "test2"
 				return secret
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 		{
 			name: "should be unequal when labels are different",
@@ -1296,10 +1534,14 @@ func Test_secretEqual(t *testing.T) {
 				}
 				return secret
 			},
-			expectedResult: false,
+			expectedResult:       false,
+			expectedDryRunResult: false,
 		},
 	}
-	for _, tc := range tests {
+}
+
+func Test_secretEqual(t *testing.T) {
+	for _, tc := range secretEqualTestCases() {
 		t.Run(tc.name, func(t *testing.T) {
 			if secretEqual(tc.getSecret1(), tc.getSecret2()) != tc.expectedResult {
 				t.Errorf("expected output to be %t", tc.expectedResult)
@@ -1307,3 +1549,563 @@ func Test_secretEqual(t *testing.T) {
 		})
 	}
 }
+
+func Test_hpaEqual(t *testing.T) {
+	defaultHPA := kcmk8shpa.NewHPA("test-companion", "test-namespace",
+		kcmk8shpa.WithLabels(map[string]string{"key": "value"}),
+		kcmk8shpa.WithScaleTargetDeployment("test-companion"),
+		kcmk8shpa.WithMinReplicas(1),
+		kcmk8shpa.WithMaxReplicas(3),
+		kcmk8shpa.WithResourceUtilizationMetric(kcorev1.ResourceCPU, 80),
+	)
+
+	testCases := map[string]struct {
+		getHPA1        func() *kautoscalingv2.HorizontalPodAutoscaler
+		getHPA2        func() *kautoscalingv2.HorizontalPodAutoscaler
+		expectedResult bool
+	}{
+		"should be equal if same default HPAs": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if one of them is nil": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return nil
+			},
+			expectedResult: false,
+		},
+		"should be equal if minReplicas is defaulted": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Spec.MinReplicas = nil
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if minReplicas changes": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Spec.MinReplicas = ptr.To(int32(2))
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if maxReplicas changes": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Spec.MaxReplicas = 5
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if metrics change": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Spec.Metrics[0].Resource.Target.AverageUtilization = ptr.To(int32(50))
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if scale target changes": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Spec.ScaleTargetRef.Name = "other"
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if labels change": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.Labels = map[string]string{"key": "other"}
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if owner references change": {
+			getHPA1: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				hpa := defaultHPA.DeepCopy()
+				hpa.OwnerReferences = []kmetav1.OwnerReference{{Name: "owner"}}
+				return hpa
+			},
+			getHPA2: func() *kautoscalingv2.HorizontalPodAutoscaler {
+				return defaultHPA.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, hpaEqual(tc.getHPA1(), tc.getHPA2()))
+		})
+	}
+}
+
+func Test_pdbEqual(t *testing.T) {
+	defaultPDB := kcmk8spdb.NewPDB("test-companion", "test-namespace",
+		kcmk8spdb.WithLabels(map[string]string{"key": "value"}),
+		kcmk8spdb.WithSelector(map[string]string{"key": "value"}),
+		kcmk8spdb.WithMaxUnavailable(intstr.FromInt32(1)),
+	)
+
+	testCases := map[string]struct {
+		getPDB1        func() *kpolicyv1.PodDisruptionBudget
+		getPDB2        func() *kpolicyv1.PodDisruptionBudget
+		expectedResult bool
+	}{
+		"should be equal if same default PDBs": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if one of them is nil": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return nil
+			},
+			expectedResult: false,
+		},
+		"should be unequal if maxUnavailable changes": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				pdb := defaultPDB.DeepCopy()
+				pdb.Spec.MaxUnavailable = ptr.To(intstr.FromString("50%"))
+				return pdb
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if minAvailable is used instead of maxUnavailable": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				pdb := defaultPDB.DeepCopy()
+				pdb.Spec.MaxUnavailable = nil
+				pdb.Spec.MinAvailable = ptr.To(intstr.FromInt32(1))
+				return pdb
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if selector changes": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				pdb := defaultPDB.DeepCopy()
+				pdb.Spec.Selector = kmetav1.SetAsLabelSelector(map[string]string{"key": "other"})
+				return pdb
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if labels change": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				pdb := defaultPDB.DeepCopy()
+				pdb.Labels = map[string]string{"key": "other"}
+				return pdb
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if owner references change": {
+			getPDB1: func() *kpolicyv1.PodDisruptionBudget {
+				pdb := defaultPDB.DeepCopy()
+				pdb.OwnerReferences = []kmetav1.OwnerReference{{Name: "owner"}}
+				return pdb
+			},
+			getPDB2: func() *kpolicyv1.PodDisruptionBudget {
+				return defaultPDB.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, pdbEqual(tc.getPDB1(), tc.getPDB2()))
+		})
+	}
+}
+
+func Test_networkPolicyEqual(t *testing.T) {
+	defaultNetworkPolicy := kcmk8snetworkpolicy.NewNetworkPolicy("test-companion", "test-namespace",
+		kcmk8snetworkpolicy.WithLabels(map[string]string{"key": "value"}),
+		kcmk8snetworkpolicy.WithPodSelector(map[string]string{"key": "value"}),
+		kcmk8snetworkpolicy.WithEgressRules(knetworkingv1.NetworkPolicyEgressRule{
+			To: []knetworkingv1.NetworkPolicyPeer{{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+		}),
+	)
+
+	testCases := map[string]struct {
+		getNetworkPolicy1 func() *knetworkingv1.NetworkPolicy
+		getNetworkPolicy2 func() *knetworkingv1.NetworkPolicy
+		expectedResult    bool
+	}{
+		"should be equal if same default NetworkPolicies": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if one of them is nil": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return nil
+			},
+			expectedResult: false,
+		},
+		"should be equal if ingress rules are nil and empty": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				networkPolicy := defaultNetworkPolicy.DeepCopy()
+				networkPolicy.Spec.Ingress = []knetworkingv1.NetworkPolicyIngressRule{}
+				return networkPolicy
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if ingress rules change": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				networkPolicy := defaultNetworkPolicy.DeepCopy()
+				networkPolicy.Spec.Ingress = []knetworkingv1.NetworkPolicyIngressRule{{}}
+				return networkPolicy
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if egress rules change": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				networkPolicy := defaultNetworkPolicy.DeepCopy()
+				networkPolicy.Spec.Egress[0].To[0].IPBlock.CIDR = "10.0.0.2/32"
+				return networkPolicy
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if pod selector changes": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				networkPolicy := defaultNetworkPolicy.DeepCopy()
+				networkPolicy.Spec.PodSelector.MatchLabels = map[string]string{"key": "other"}
+				return networkPolicy
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if owner references change": {
+			getNetworkPolicy1: func() *knetworkingv1.NetworkPolicy {
+				networkPolicy := defaultNetworkPolicy.DeepCopy()
+				networkPolicy.OwnerReferences = []kmetav1.OwnerReference{{Name: "owner"}}
+				return networkPolicy
+			},
+			getNetworkPolicy2: func() *knetworkingv1.NetworkPolicy {
+				return defaultNetworkPolicy.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, networkPolicyEqual(tc.getNetworkPolicy1(), tc.getNetworkPolicy2()))
+		})
+	}
+}
+
+func Test_serviceAccountEqual(t *testing.T) {
+	defaultServiceAccount := kcmk8sserviceaccount.NewServiceAccount("test-companion", "test-namespace",
+		kcmk8sserviceaccount.WithLabels(map[string]string{"key": "value"}),
+	)
+
+	testCases := map[string]struct {
+		getServiceAccount1 func() *kcorev1.ServiceAccount
+		getServiceAccount2 func() *kcorev1.ServiceAccount
+		expectedResult     bool
+	}{
+		"should be equal if same default ServiceAccounts": {
+			getServiceAccount1: func() *kcorev1.ServiceAccount {
+				return defaultServiceAccount.DeepCopy()
+			},
+			getServiceAccount2: func() *kcorev1.ServiceAccount {
+				return defaultServiceAccount.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be equal if only the secrets are different": {
+			getServiceAccount1: func() *kcorev1.ServiceAccount {
+				serviceAccount := defaultServiceAccount.DeepCopy()
+				serviceAccount.Secrets = []kcorev1.ObjectReference{{Name: "token"}}
+				return serviceAccount
+			},
+			getServiceAccount2: func() *kcorev1.ServiceAccount {
+				return defaultServiceAccount.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if labels change": {
+			getServiceAccount1: func() *kcorev1.ServiceAccount {
+				serviceAccount := defaultServiceAccount.DeepCopy()
+				serviceAccount.Labels = map[string]string{"key": "other"}
+				return serviceAccount
+			},
+			getServiceAccount2: func() *kcorev1.ServiceAccount {
+				return defaultServiceAccount.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if owner references change": {
+			getServiceAccount1: func() *kcorev1.ServiceAccount {
+				serviceAccount := defaultServiceAccount.DeepCopy()
+				serviceAccount.OwnerReferences = []kmetav1.OwnerReference{{Name: "owner"}}
+				return serviceAccount
+			},
+			getServiceAccount2: func() *kcorev1.ServiceAccount {
+				return defaultServiceAccount.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, serviceAccountEqual(tc.getServiceAccount1(), tc.getServiceAccount2()))
+		})
+	}
+}
+
+func Test_clusterRoleEqual(t *testing.T) {
+	defaultClusterRole := kcmk8srbac.NewClusterRole("test-companion",
+		kcmk8srbac.WithClusterRoleLabels(map[string]string{"key": "value"}),
+		kcmk8srbac.WithRule([]string{""}, []string{"pods"}, []string{"get", "list", "watch"}),
+	)
+
+	testCases := map[string]struct {
+		getClusterRole1 func() *krbacv1.ClusterRole
+		getClusterRole2 func() *krbacv1.ClusterRole
+		expectedResult  bool
+	}{
+		"should be equal if same default ClusterRoles": {
+			getClusterRole1: func() *krbacv1.ClusterRole {
+				return defaultClusterRole.DeepCopy()
+			},
+			getClusterRole2: func() *krbacv1.ClusterRole {
+				return defaultClusterRole.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if one of them is nil": {
+			getClusterRole1: func() *krbacv1.ClusterRole {
+				return defaultClusterRole.DeepCopy()
+			},
+			getClusterRole2: func() *krbacv1.ClusterRole {
+				return nil
+			},
+			expectedResult: false,
+		},
+		"should be unequal if rules change": {
+			getClusterRole1: func() *krbacv1.ClusterRole {
+				clusterRole := defaultClusterRole.DeepCopy()
+				clusterRole.Rules[0].Verbs = append(clusterRole.Rules[0].Verbs, "delete")
+				return clusterRole
+			},
+			getClusterRole2: func() *krbacv1.ClusterRole {
+				return defaultClusterRole.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if labels change": {
+			getClusterRole1: func() *krbacv1.ClusterRole {
+				clusterRole := defaultClusterRole.DeepCopy()
+				clusterRole.Labels = map[string]string{"key": "other"}
+				return clusterRole
+			},
+			getClusterRole2: func() *krbacv1.ClusterRole {
+				return defaultClusterRole.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, clusterRoleEqual(tc.getClusterRole1(), tc.getClusterRole2()))
+		})
+	}
+}
+
+func Test_clusterRoleBindingEqual(t *testing.T) {
+	defaultClusterRoleBinding := kcmk8srbac.NewClusterRoleBinding("test-companion",
+		kcmk8srbac.WithClusterRoleBindingLabels(map[string]string{"key": "value"}),
+		kcmk8srbac.WithClusterRoleRef("test-companion"),
+		kcmk8srbac.WithServiceAccountSubject("test-companion", "test-namespace"),
+	)
+
+	testCases := map[string]struct {
+		getClusterRoleBinding1 func() *krbacv1.ClusterRoleBinding
+		getClusterRoleBinding2 func() *krbacv1.ClusterRoleBinding
+		expectedResult         bool
+	}{
+		"should be equal if same default ClusterRoleBindings": {
+			getClusterRoleBinding1: func() *krbacv1.ClusterRoleBinding {
+				return defaultClusterRoleBinding.DeepCopy()
+			},
+			getClusterRoleBinding2: func() *krbacv1.ClusterRoleBinding {
+				return defaultClusterRoleBinding.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if subjects change": {
+			getClusterRoleBinding1: func() *krbacv1.ClusterRoleBinding {
+				clusterRoleBinding := defaultClusterRoleBinding.DeepCopy()
+				clusterRoleBinding.Subjects[0].Namespace = "other"
+				return clusterRoleBinding
+			},
+			getClusterRoleBinding2: func() *krbacv1.ClusterRoleBinding {
+				return defaultClusterRoleBinding.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if role ref changes": {
+			getClusterRoleBinding1: func() *krbacv1.ClusterRoleBinding {
+				clusterRoleBinding := defaultClusterRoleBinding.DeepCopy()
+				clusterRoleBinding.RoleRef.Name = "other"
+				return clusterRoleBinding
+			},
+			getClusterRoleBinding2: func() *krbacv1.ClusterRoleBinding {
+				return defaultClusterRoleBinding.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult,
+				clusterRoleBindingEqual(tc.getClusterRoleBinding1(), tc.getClusterRoleBinding2()))
+		})
+	}
+}
+
+func Test_unstructuredEqual(t *testing.T) {
+	defaultAPIRule := kcmk8sapirule.NewAPIRule("test-companion", "test-namespace",
+		kcmk8sapirule.WithLabels(map[string]string{"key": "value"}),
+		kcmk8sapirule.WithHost("companion.example.com"),
+		kcmk8sapirule.WithService("test-companion", 8000),
+	)
+
+	testCases := map[string]struct {
+		getObject1     func() *kunstructured.Unstructured
+		getObject2     func() *kunstructured.Unstructured
+		expectedResult bool
+	}{
+		"should be equal if same default objects": {
+			getObject1: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be equal if only the status and server-side metadata are different": {
+			getObject1: func() *kunstructured.Unstructured {
+				apiRule := defaultAPIRule.DeepCopy()
+				apiRule.SetResourceVersion("123")
+				apiRule.Object["status"] = map[string]interface{}{"code": "OK"}
+				return apiRule
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			expectedResult: true,
+		},
+		"should be unequal if one of them is nil": {
+			getObject1: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return nil
+			},
+			expectedResult: false,
+		},
+		"should be unequal if the spec changes": {
+			getObject1: func() *kunstructured.Unstructured {
+				apiRule := defaultAPIRule.DeepCopy()
+				apiRule.Object["spec"].(map[string]interface{})["host"] = "other.example.com"
+				return apiRule
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if the kind changes": {
+			getObject1: func() *kunstructured.Unstructured {
+				apiRule := defaultAPIRule.DeepCopy()
+				apiRule.SetKind("VirtualService")
+				return apiRule
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			expectedResult: false,
+		},
+		"should be unequal if labels change": {
+			getObject1: func() *kunstructured.Unstructured {
+				apiRule := defaultAPIRule.DeepCopy()
+				apiRule.SetLabels(map[string]string{"key": "other"})
+				return apiRule
+			},
+			getObject2: func() *kunstructured.Unstructured {
+				return defaultAPIRule.DeepCopy()
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, unstructuredEqual(tc.getObject1(), tc.getObject2()))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package apirule
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// APIRule is a custom resource of the Kyma API gateway module. It is handled as unstructured object, so that
+// the Kyma companion manager does not depend on the API gateway module being installed.
+const (
+	Kind       = "APIRule"
+	APIVersion = "gateway.kyma-project.io/v1beta1"
+
+	// HandlerJWT is the access strategy which requires a JSON Web Token.
+	HandlerJWT = "jwt"
+	// HandlerOAuth2Introspection is the access strategy which requires an OAuth2 access token.
+	HandlerOAuth2Introspection = "oauth2_introspection"
+)
+
+// GroupVersionResource of the APIRule, which is used to access it through the dynamic client.
+//
+//nolint:gochecknoglobals // used as constant.
+var GroupVersionResource = kschema.GroupVersionResource{
+	Group:    "gateway.kyma-project.io",
+	Version:  "v1beta1",
+	Resource: "apirules",
+}
+
+// AccessStrategy authenticates the requests matching a rule of the APIRule.
+type AccessStrategy struct {
+	Handler string
+	Config  map[string]interface{}
+}
+
+type Opt func(apiRule *kunstructured.Unstructured)
+
+func NewAPIRule(name, namespace string, opts ...Opt) *kunstructured.Unstructured {
+	newAPIRule := &kunstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{},
+	}}
+	newAPIRule.SetAPIVersion(APIVersion)
+	newAPIRule.SetKind(Kind)
+	newAPIRule.SetName(name)
+	newAPIRule.SetNamespace(namespace)
+	// apply options.
+	for _, o := range opts {
+		o(newAPIRule)
+	}
+	return newAPIRule
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(a *kunstructured.Unstructured) {
+		a.SetLabels(labels)
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(a *kunstructured.Unstructured) {
+		a.SetOwnerReferences(ownerReferences)
+	}
+}
+
+func WithHost(host string) Opt {
+	return func(a *kunstructured.Unstructured) {
+		spec(a)["host"] = host
+	}
+}
+
+// WithGateway sets the Istio gateway in the format `<namespace>/<name>`.
+func WithGateway(gateway string) Opt {
+	return func(a *kunstructured.Unstructured) {
+		spec(a)["gateway"] = gateway
+	}
+}
+
+// WithService sets the Service to which the requests are forwarded.
+func WithService(name string, port int32) Opt {
+	return func(a *kunstructured.Unstructured) {
+		spec(a)["service"] = map[string]interface{}{
+			"name": name,
+			"port": int64(port),
+		}
+	}
+}
+
+// WithRule adds a rule which allows the given methods on the given path, if the request passes
+// the access strategies.
+func WithRule(path string, methods []string, accessStrategies ...AccessStrategy) Opt {
+	return func(a *kunstructured.Unstructured) {
+		strategies := make([]interface{}, 0, len(accessStrategies))
+		for _, strategy := range accessStrategies {
+			s := map[string]interface{}{"handler": strategy.Handler}
+			if strategy.Config != nil {
+				s["config"] = strategy.Config
+			}
+			strategies = append(strategies, s)
+		}
+		rules, _ := spec(a)["rules"].([]interface{})
+		spec(a)["rules"] = append(rules, map[string]interface{}{
+			"path":             path,
+			"methods":          toInterfaceSlice(methods),
+			"accessStrategies": strategies,
+		})
+	}
+}
+
+// spec returns the spec of the given object, which is created if it does not exist.
+func spec(a *kunstructured.Unstructured) map[string]interface{} {
+	s, ok := a.Object["spec"].(map[string]interface{})
+	if !ok {
+		s = map[string]interface{}{}
+		a.Object["spec"] = s
+	}
+	return s
+}
+
+// toInterfaceSlice converts the given strings, as unstructured objects only support slices of interfaces.
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
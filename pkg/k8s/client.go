@@ -2,15 +2,27 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// FieldManager is the name of the field manager of the kyma-companion-manager for the server-side apply.
+const FieldManager = "kyma-companion-manager"
+
 //go:generate go run github.com/vektra/mockery/v2 --name=Client --outpkg=mocks --case=underscore
 type Client interface {
 	GetDeployment(ctx context.Context, name, namespace string) (*kappsv1.Deployment, error)
@@ -18,8 +30,24 @@ type Client interface {
 	DeleteDeployment(ctx context.Context, name, namespace string) error
 	GetSecret(ctx context.Context, name, namespace string) (*kcorev1.Secret, error)
 	GetConfigMap(ctx context.Context, name, namespace string) (*kcorev1.ConfigMap, error)
+	GetHorizontalPodAutoscaler(ctx context.Context, name, namespace string) (
+		*kautoscalingv2.HorizontalPodAutoscaler, error)
+	GetService(ctx context.Context, name, namespace string) (*kcorev1.Service, error)
+	GetPodDisruptionBudget(ctx context.Context, name, namespace string) (*kpolicyv1.PodDisruptionBudget, error)
+	GetNetworkPolicy(ctx context.Context, name, namespace string) (*knetworkingv1.NetworkPolicy, error)
+	GetServiceAccount(ctx context.Context, name, namespace string) (*kcorev1.ServiceAccount, error)
+	GetClusterRole(ctx context.Context, name string) (*krbacv1.ClusterRole, error)
+	GetClusterRoleBinding(ctx context.Context, name string) (*krbacv1.ClusterRoleBinding, error)
+	GetUnstructured(ctx context.Context, resource kschema.GroupVersionResource, name, namespace string) (
+		*kunstructured.Unstructured, error)
+	ApplyUnstructured(ctx context.Context, resource kschema.GroupVersionResource,
+		object *kunstructured.Unstructured) error
+	DeleteUnstructured(ctx context.Context, resource kschema.GroupVersionResource, name, namespace string) error
+	ListPods(ctx context.Context, namespace string, selector *kmetav1.LabelSelector) ([]kcorev1.Pod, error)
 	DeleteResource(ctx context.Context, object client.Object) error
+	ResourceExists(ctx context.Context, object client.Object) (bool, error)
 	PatchApply(ctx context.Context, object client.Object) error
+	PatchApplyDryRun(ctx context.Context, object client.Object) (client.Object, error)
 }
 
 type KubeClient struct {
@@ -70,6 +98,22 @@ func (c *KubeClient) DeleteResource(ctx context.Context, object client.Object) e
 	return nil
 }
 
+// ResourceExists returns true if a resource with the kind, name and namespace of the given object exists.
+// The given object is not modified.
+func (c *KubeClient) ResourceExists(ctx context.Context, object client.Object) (bool, error) {
+	existing, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return false, fmt.Errorf("failed to copy object %s/%s", object.GetNamespace(), object.GetName())
+	}
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(object), existing); err != nil {
+		if kapierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // PatchApply uses the server-side apply to create/update the resource.
 // The object must define `GVK` (i.e. object.TypeMeta).
 func (c *KubeClient) PatchApply(ctx context.Context, object client.Object) error {
@@ -79,6 +123,23 @@ func (c *KubeClient) PatchApply(ctx context.Context, object client.Object) error
 	})
 }
 
+// PatchApplyDryRun uses the server-side apply in dry-run mode to compute the resource as it would be persisted,
+// without persisting it. The given object is not modified, and must define `GVK` (i.e. object.TypeMeta).
+func (c *KubeClient) PatchApplyDryRun(ctx context.Context, object client.Object) (client.Object, error) {
+	result, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("failed to copy object %s/%s", object.GetNamespace(), object.GetName())
+	}
+	if err := c.client.Patch(ctx, result, client.Apply, &client.PatchOptions{
+		DryRun:       []string{kmetav1.DryRunAll},
+		Force:        ptr.To(true),
+		FieldManager: c.fieldManager,
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetSecret returns the secret with the given name.
 func (c *KubeClient) GetSecret(ctx context.Context, name, namespace string) (*kcorev1.Secret, error) {
 	secret := &kcorev1.Secret{}
@@ -101,3 +162,135 @@ func (c *KubeClient) GetConfigMap(ctx context.Context, name, namespace string) (
 	}
 	return cm, nil
 }
+
+// GetHorizontalPodAutoscaler returns the HorizontalPodAutoscaler with the given name and namespace.
+// It returns nil, if the HorizontalPodAutoscaler does not exist.
+func (c *KubeClient) GetHorizontalPodAutoscaler(ctx context.Context, name, namespace string,
+) (*kautoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := &kautoscalingv2.HorizontalPodAutoscaler{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, hpa); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return hpa, nil
+}
+
+// GetService returns the Service with the given name and namespace.
+// It returns nil, if the Service does not exist.
+func (c *KubeClient) GetService(ctx context.Context, name, namespace string) (*kcorev1.Service, error) {
+	service := &kcorev1.Service{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, service); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return service, nil
+}
+
+// GetPodDisruptionBudget returns the PodDisruptionBudget with the given name and namespace.
+// It returns nil, if the PodDisruptionBudget does not exist.
+func (c *KubeClient) GetPodDisruptionBudget(ctx context.Context, name, namespace string,
+) (*kpolicyv1.PodDisruptionBudget, error) {
+	pdb := &kpolicyv1.PodDisruptionBudget{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, pdb); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return pdb, nil
+}
+
+// GetNetworkPolicy returns the NetworkPolicy with the given name and namespace.
+// It returns nil, if the NetworkPolicy does not exist.
+func (c *KubeClient) GetNetworkPolicy(ctx context.Context, name, namespace string,
+) (*knetworkingv1.NetworkPolicy, error) {
+	networkPolicy := &knetworkingv1.NetworkPolicy{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, networkPolicy); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return networkPolicy, nil
+}
+
+// GetServiceAccount returns the ServiceAccount with the given name and namespace.
+// It returns nil, if the ServiceAccount does not exist.
+func (c *KubeClient) GetServiceAccount(ctx context.Context, name, namespace string,
+) (*kcorev1.ServiceAccount, error) {
+	serviceAccount := &kcorev1.ServiceAccount{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, serviceAccount); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return serviceAccount, nil
+}
+
+// GetClusterRole returns the ClusterRole with the given name.
+// It returns nil, if the ClusterRole does not exist.
+func (c *KubeClient) GetClusterRole(ctx context.Context, name string) (*krbacv1.ClusterRole, error) {
+	clusterRole := &krbacv1.ClusterRole{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name}, clusterRole); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return clusterRole, nil
+}
+
+// GetClusterRoleBinding returns the ClusterRoleBinding with the given name.
+// It returns nil, if the ClusterRoleBinding does not exist.
+func (c *KubeClient) GetClusterRoleBinding(ctx context.Context, name string) (*krbacv1.ClusterRoleBinding, error) {
+	clusterRoleBinding := &krbacv1.ClusterRoleBinding{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name}, clusterRoleBinding); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return clusterRoleBinding, nil
+}
+
+// GetUnstructured returns the resource of the given type with the given name and namespace through the dynamic
+// client. It returns nil, if the resource does not exist, or if its CustomResourceDefinition is not installed.
+func (c *KubeClient) GetUnstructured(ctx context.Context, resource kschema.GroupVersionResource,
+	name, namespace string,
+) (*kunstructured.Unstructured, error) {
+	object, err := c.dynamicClient.Resource(resource).Namespace(namespace).Get(ctx, name, kmetav1.GetOptions{})
+	if err != nil {
+		if isNotFoundOrNoMatch(err) {
+			return nil, nil //nolint:nilnil // the resource or its CustomResourceDefinition does not exist.
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// ApplyUnstructured uses the server-side apply to create/update the given resource through the dynamic client.
+func (c *KubeClient) ApplyUnstructured(ctx context.Context, resource kschema.GroupVersionResource,
+	object *kunstructured.Unstructured,
+) error {
+	_, err := c.dynamicClient.Resource(resource).Namespace(object.GetNamespace()).Apply(ctx, object.GetName(),
+		object, kmetav1.ApplyOptions{FieldManager: c.fieldManager, Force: true})
+	return err
+}
+
+// DeleteUnstructured deletes the resource of the given type with the given name and namespace through the
+// dynamic client. It ignores resources which do not exist, or whose CustomResourceDefinition is not installed.
+func (c *KubeClient) DeleteUnstructured(ctx context.Context, resource kschema.GroupVersionResource,
+	name, namespace string,
+) error {
+	err := c.dynamicClient.Resource(resource).Namespace(namespace).Delete(ctx, name, kmetav1.DeleteOptions{})
+	if err != nil && !isNotFoundOrNoMatch(err) {
+		return err
+	}
+	return nil
+}
+
+// isNotFoundOrNoMatch returns true if the given error reports that a resource does not exist. The API server
+// reports a missing CustomResourceDefinition as NotFound, whereas clients with a RESTMapper report it as NoMatch.
+func isNotFoundOrNoMatch(err error) bool {
+	return kapierrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+// ListPods returns the pods in the given namespace which match the given label selector.
+func (c *KubeClient) ListPods(ctx context.Context, namespace string,
+	selector *kmetav1.LabelSelector,
+) ([]kcorev1.Pod, error) {
+	labelSelector, err := kmetav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods := &kcorev1.PodList{}
+	if err = c.client.List(ctx, pods, client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
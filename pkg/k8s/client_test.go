@@ -6,12 +6,25 @@ import (
 
 	"github.com/stretchr/testify/require"
 	kappsv1 "k8s.io/api/apps/v1"
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
 	kcorev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kpolicyv1 "k8s.io/api/policy/v1"
+	krbacv1 "k8s.io/api/rbac/v1"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kdynamicfake "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
 )
@@ -191,6 +204,92 @@ func Test_DeleteDeployment(t *testing.T) {
 	}
 }
 
+func Test_ResourceExists(t *testing.T) {
+	t.Parallel()
+	// Define test cases
+	testCases := []struct {
+		name                  string
+		givenDeploymentExists bool
+	}{
+		{
+			name:                  "should return true when the resource exists",
+			givenDeploymentExists: true,
+		},
+		{
+			name:                  "should return false when the resource does not exist",
+			givenDeploymentExists: false,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			givenDeployment := &kappsv1.Deployment{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-deployment",
+					Namespace: "test-namespace",
+				},
+			}
+			var givenObjs []client.Object
+			if testcase.givenDeploymentExists {
+				givenObjs = append(givenObjs, givenDeployment.DeepCopy())
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			exists, err := kubeClient.ResourceExists(ctx, givenDeployment)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, testcase.givenDeploymentExists, exists)
+			require.Empty(t, givenDeployment.ResourceVersion, "ResourceExists must not modify the given object")
+		})
+	}
+}
+
+func Test_PatchApplyDryRun(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	givenDeployment := testutils.NewDeployment("test-deployment", "test-namespace", map[string]string{})
+	var gotOptions *client.PatchOptions
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch,
+			opts ...client.PatchOption,
+		) error {
+			require.Equal(t, client.Apply, patch)
+			gotOptions = &client.PatchOptions{}
+			gotOptions.ApplyOptions(opts)
+			// simulate a field defaulted by the API server.
+			obj.SetLabels(map[string]string{"defaulted": "true"})
+			return nil
+		},
+	}).Build()
+	kubeClient := &KubeClient{
+		client:       fakeClient,
+		fieldManager: "test-manager",
+	}
+
+	// when
+	result, err := kubeClient.PatchApplyDryRun(ctx, givenDeployment)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"defaulted": "true"}, result.GetLabels())
+	require.Empty(t, givenDeployment.GetLabels(), "PatchApplyDryRun must not modify the given object")
+	require.Equal(t, []string{kmetav1.DryRunAll}, gotOptions.DryRun)
+	require.Equal(t, "test-manager", gotOptions.FieldManager)
+	require.True(t, *gotOptions.Force)
+}
+
 func Test_GetSecret(t *testing.T) {
 	t.Parallel()
 	// Define test cases as a table.
@@ -309,3 +408,559 @@ func Test_GetConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetHorizontalPodAutoscaler(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name      string
+		givenHPA  *kautoscalingv2.HorizontalPodAutoscaler
+		wantFound bool
+	}{
+		{
+			name: "should return the HorizontalPodAutoscaler when it exists",
+			givenHPA: &kautoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-hpa",
+					Namespace: "test-namespace",
+				},
+				Spec: kautoscalingv2.HorizontalPodAutoscalerSpec{
+					MaxReplicas: 3,
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the HorizontalPodAutoscaler does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenHPA != nil {
+				givenObjs = append(givenObjs, testcase.givenHPA)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotHPA, err := kubeClient.GetHorizontalPodAutoscaler(ctx, "test-hpa", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotHPA)
+				return
+			}
+			require.NotNil(t, gotHPA)
+			require.Equal(t, testcase.givenHPA.Spec, gotHPA.Spec)
+		})
+	}
+}
+func Test_GetPodDisruptionBudget(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name      string
+		givenPDB  *kpolicyv1.PodDisruptionBudget
+		wantFound bool
+	}{
+		{
+			name: "should return the PodDisruptionBudget when it exists",
+			givenPDB: &kpolicyv1.PodDisruptionBudget{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-pdb",
+					Namespace: "test-namespace",
+				},
+				Spec: kpolicyv1.PodDisruptionBudgetSpec{
+					MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the PodDisruptionBudget does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenPDB != nil {
+				givenObjs = append(givenObjs, testcase.givenPDB)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotPDB, err := kubeClient.GetPodDisruptionBudget(ctx, "test-pdb", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotPDB)
+				return
+			}
+			require.NotNil(t, gotPDB)
+			require.Equal(t, testcase.givenPDB.Spec, gotPDB.Spec)
+		})
+	}
+}
+
+func Test_GetNetworkPolicy(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name               string
+		givenNetworkPolicy *knetworkingv1.NetworkPolicy
+		wantFound          bool
+	}{
+		{
+			name: "should return the NetworkPolicy when it exists",
+			givenNetworkPolicy: &knetworkingv1.NetworkPolicy{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-network-policy",
+					Namespace: "test-namespace",
+				},
+				Spec: knetworkingv1.NetworkPolicySpec{
+					PolicyTypes: []knetworkingv1.PolicyType{knetworkingv1.PolicyTypeIngress},
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the NetworkPolicy does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenNetworkPolicy != nil {
+				givenObjs = append(givenObjs, testcase.givenNetworkPolicy)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotNetworkPolicy, err := kubeClient.GetNetworkPolicy(ctx, "test-network-policy", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotNetworkPolicy)
+				return
+			}
+			require.NotNil(t, gotNetworkPolicy)
+			require.Equal(t, testcase.givenNetworkPolicy.Spec, gotNetworkPolicy.Spec)
+		})
+	}
+}
+
+func Test_GetServiceAccount(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name                string
+		givenServiceAccount *kcorev1.ServiceAccount
+		wantFound           bool
+	}{
+		{
+			name: "should return the ServiceAccount when it exists",
+			givenServiceAccount: &kcorev1.ServiceAccount{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-service-account",
+					Namespace: "test-namespace",
+					Labels:    map[string]string{"key": "value"},
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the ServiceAccount does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenServiceAccount != nil {
+				givenObjs = append(givenObjs, testcase.givenServiceAccount)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotServiceAccount, err := kubeClient.GetServiceAccount(ctx, "test-service-account", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotServiceAccount)
+				return
+			}
+			require.NotNil(t, gotServiceAccount)
+			require.Equal(t, testcase.givenServiceAccount.Labels, gotServiceAccount.Labels)
+		})
+	}
+}
+
+func Test_GetClusterRole(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name             string
+		givenClusterRole *krbacv1.ClusterRole
+		wantFound        bool
+	}{
+		{
+			name: "should return the ClusterRole when it exists",
+			givenClusterRole: &krbacv1.ClusterRole{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name: "test-cluster-role",
+				},
+				Rules: []krbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the ClusterRole does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenClusterRole != nil {
+				givenObjs = append(givenObjs, testcase.givenClusterRole)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotClusterRole, err := kubeClient.GetClusterRole(ctx, "test-cluster-role")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotClusterRole)
+				return
+			}
+			require.NotNil(t, gotClusterRole)
+			require.Equal(t, testcase.givenClusterRole.Rules, gotClusterRole.Rules)
+		})
+	}
+}
+
+func Test_GetClusterRoleBinding(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name                    string
+		givenClusterRoleBinding *krbacv1.ClusterRoleBinding
+		wantFound               bool
+	}{
+		{
+			name: "should return the ClusterRoleBinding when it exists",
+			givenClusterRoleBinding: &krbacv1.ClusterRoleBinding{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name: "test-cluster-role-binding",
+				},
+				RoleRef: krbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "test-cluster-role",
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the ClusterRoleBinding does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenClusterRoleBinding != nil {
+				givenObjs = append(givenObjs, testcase.givenClusterRoleBinding)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotClusterRoleBinding, err := kubeClient.GetClusterRoleBinding(ctx, "test-cluster-role-binding")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotClusterRoleBinding)
+				return
+			}
+			require.NotNil(t, gotClusterRoleBinding)
+			require.Equal(t, testcase.givenClusterRoleBinding.RoleRef, gotClusterRoleBinding.RoleRef)
+		})
+	}
+}
+
+func Test_GetService(t *testing.T) {
+	t.Parallel()
+	// Define test cases as a table.
+	testCases := []struct {
+		name         string
+		givenService *kcorev1.Service
+		wantFound    bool
+	}{
+		{
+			name: "should return the Service when it exists",
+			givenService: &kcorev1.Service{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "test-namespace",
+				},
+				Spec: kcorev1.ServiceSpec{
+					Ports: []kcorev1.ServicePort{{Name: "http", Port: 8000}},
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:      "should return nil when the Service does not exist",
+			wantFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []client.Object
+			if testcase.givenService != nil {
+				givenObjs = append(givenObjs, testcase.givenService)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(givenObjs...).Build()
+			kubeClient := &KubeClient{
+				client: fakeClient,
+			}
+
+			// when
+			gotService, err := kubeClient.GetService(ctx, "test-service", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			if !testcase.wantFound {
+				require.Nil(t, gotService)
+				return
+			}
+			require.NotNil(t, gotService)
+			require.Equal(t, testcase.givenService.Spec, gotService.Spec)
+		})
+	}
+}
+
+func Test_ListPods(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenLabels := map[string]string{"app": "backend"}
+	givenPods := []client.Object{
+		&kcorev1.Pod{ObjectMeta: kmetav1.ObjectMeta{Name: "match", Namespace: "test-ns", Labels: givenLabels}},
+		&kcorev1.Pod{ObjectMeta: kmetav1.ObjectMeta{Name: "other-labels", Namespace: "test-ns"}},
+		&kcorev1.Pod{ObjectMeta: kmetav1.ObjectMeta{Name: "other-ns", Namespace: "other-ns", Labels: givenLabels}},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(givenPods...).Build()
+	kubeClient := &KubeClient{
+		client: fakeClient,
+	}
+
+	// when
+	gotPods, err := kubeClient.ListPods(context.Background(), "test-ns", kmetav1.SetAsLabelSelector(givenLabels))
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, gotPods, 1)
+	require.Equal(t, "match", gotPods[0].GetName())
+}
+
+func Test_GetUnstructured(t *testing.T) {
+	t.Parallel()
+
+	givenGVR := kschema.GroupVersionResource{Group: "gateway.kyma-project.io", Version: "v1beta1", Resource: "apirules"}
+	forbiddenErr := kapierrors.NewForbidden(givenGVR.GroupResource(), "test-api-rule", nil)
+
+	// Define test cases as a table.
+	testCases := []struct {
+		name        string
+		givenObject *kunstructured.Unstructured
+		givenError  error
+		wantFound   bool
+		wantError   error
+	}{
+		{
+			name:        "should return the resource when it exists",
+			givenObject: newTestAPIRule("test-api-rule", "test-namespace"),
+			wantFound:   true,
+		},
+		{
+			name:      "should return nil when the resource does not exist",
+			wantFound: false,
+		},
+		{
+			name: "should return nil when the CustomResourceDefinition is not installed",
+			givenError: &meta.NoKindMatchError{
+				GroupKind: kschema.GroupKind{Group: givenGVR.Group, Kind: "APIRule"},
+			},
+			wantFound: false,
+		},
+		{
+			name:       "should return an error when the resource cannot be fetched",
+			givenError: forbiddenErr,
+			wantError:  forbiddenErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []kruntime.Object
+			if testcase.givenObject != nil {
+				givenObjs = append(givenObjs, testcase.givenObject)
+			}
+			dynamicClient := newTestDynamicClient(givenGVR, givenObjs...)
+			if testcase.givenError != nil {
+				dynamicClient.PrependReactor("get", givenGVR.Resource,
+					func(_ ktesting.Action) (bool, kruntime.Object, error) {
+						return true, nil, testcase.givenError
+					})
+			}
+			kubeClient := &KubeClient{
+				dynamicClient: dynamicClient,
+			}
+
+			// when
+			gotObject, err := kubeClient.GetUnstructured(ctx, givenGVR, "test-api-rule", "test-namespace")
+
+			// then
+			require.ErrorIs(t, err, testcase.wantError)
+			if !testcase.wantFound {
+				require.Nil(t, gotObject)
+				return
+			}
+			require.NotNil(t, gotObject)
+			require.Equal(t, testcase.givenObject.Object["spec"], gotObject.Object["spec"])
+		})
+	}
+}
+
+func Test_DeleteUnstructured(t *testing.T) {
+	t.Parallel()
+
+	givenGVR := kschema.GroupVersionResource{Group: "gateway.kyma-project.io", Version: "v1beta1", Resource: "apirules"}
+
+	// Define test cases as a table.
+	testCases := []struct {
+		name        string
+		givenObject *kunstructured.Unstructured
+	}{
+		{
+			name:        "should delete the resource when it exists",
+			givenObject: newTestAPIRule("test-api-rule", "test-namespace"),
+		},
+		{
+			name: "should not return error when the resource does not exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			ctx := context.Background()
+			var givenObjs []kruntime.Object
+			if testcase.givenObject != nil {
+				givenObjs = append(givenObjs, testcase.givenObject)
+			}
+			kubeClient := &KubeClient{
+				dynamicClient: newTestDynamicClient(givenGVR, givenObjs...),
+			}
+
+			// when
+			err := kubeClient.DeleteUnstructured(ctx, givenGVR, "test-api-rule", "test-namespace")
+
+			// then
+			require.NoError(t, err)
+			gotObject, err := kubeClient.GetUnstructured(ctx, givenGVR, "test-api-rule", "test-namespace")
+			require.NoError(t, err)
+			require.Nil(t, gotObject)
+		})
+	}
+}
+
+func newTestDynamicClient(gvr kschema.GroupVersionResource,
+	objects ...kruntime.Object,
+) *kdynamicfake.FakeDynamicClient {
+	return kdynamicfake.NewSimpleDynamicClientWithCustomListKinds(kruntime.NewScheme(),
+		map[kschema.GroupVersionResource]string{gvr: "APIRuleList"}, objects...)
+}
+
+func newTestAPIRule(name, namespace string) *kunstructured.Unstructured {
+	apiRule := &kunstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"host": "companion.example.com"},
+	}}
+	apiRule.SetAPIVersion("gateway.kyma-project.io/v1beta1")
+	apiRule.SetKind("APIRule")
+	apiRule.SetName(name)
+	apiRule.SetNamespace(namespace)
+	return apiRule
+}
@@ -1,6 +1,8 @@
 package deployment
 
 import (
+	"maps"
+
 	kappsv1 "k8s.io/api/apps/v1"
 	kcorev1 "k8s.io/api/core/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,6 +48,29 @@ func WithLabels(labels map[string]string) Opt {
 	}
 }
 
+// WithPodLabels adds the given labels to the pod template only, e.g. to enable the injection of a sidecar.
+// It must be applied after WithLabels.
+func WithPodLabels(labels map[string]string) Opt {
+	return func(d *kappsv1.Deployment) {
+		podLabels := make(map[string]string, len(d.Spec.Template.ObjectMeta.Labels)+len(labels))
+		maps.Copy(podLabels, d.Spec.Template.ObjectMeta.Labels)
+		maps.Copy(podLabels, labels)
+		d.Spec.Template.ObjectMeta.Labels = podLabels
+	}
+}
+
+// WithPodAnnotations adds the given annotations to the pod template, so that a change of them rolls the pods.
+func WithPodAnnotations(annotations map[string]string) Opt {
+	return func(d *kappsv1.Deployment) {
+		if d.Spec.Template.ObjectMeta.Annotations == nil {
+			d.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+		}
+		for key, value := range annotations {
+			d.Spec.Template.ObjectMeta.Annotations[key] = value
+		}
+	}
+}
+
 func WithSelectorLabels(labels map[string]string) Opt {
 	return func(d *kappsv1.Deployment) {
 		d.Spec.Selector = kmetav1.SetAsLabelSelector(labels)
@@ -88,6 +113,12 @@ func WithSecurityContext(securityContext *kcorev1.PodSecurityContext) Opt {
 	}
 }
 
+func WithServiceAccountName(serviceAccountName string) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		deployment.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+	}
+}
+
 func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
 	return func(deployment *kappsv1.Deployment) {
 		deployment.OwnerReferences = ownerReferences
@@ -113,3 +144,40 @@ func WithVolumeMountedSecret(secretName string) Opt {
 		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
 	}
 }
+
+func WithEmptyDirVolume(volumeName string) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		volume := kcorev1.Volume{
+			Name: volumeName,
+			VolumeSource: kcorev1.VolumeSource{
+				EmptyDir: &kcorev1.EmptyDirVolumeSource{},
+			},
+		}
+
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+	}
+}
+
+func WithTolerations(tolerations []kcorev1.Toleration) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		deployment.Spec.Template.Spec.Tolerations = tolerations
+	}
+}
+
+func WithAffinity(affinity *kcorev1.Affinity) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		deployment.Spec.Template.Spec.Affinity = affinity
+	}
+}
+
+func WithTopologySpreadConstraints(constraints []kcorev1.TopologySpreadConstraint) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = constraints
+	}
+}
+
+func WithNodeSelector(nodeSelector map[string]string) Opt {
+	return func(deployment *kappsv1.Deployment) {
+		deployment.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+}
@@ -0,0 +1,82 @@
+package hpa
+
+import (
+	kautoscalingv2 "k8s.io/api/autoscaling/v2"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/kyma-companion-manager/pkg/utils"
+)
+
+type Opt func(hpa *kautoscalingv2.HorizontalPodAutoscaler)
+
+func NewHPA(name, namespace string, opts ...Opt) *kautoscalingv2.HorizontalPodAutoscaler {
+	newHPA := &kautoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kautoscalingv2.HorizontalPodAutoscalerSpec{},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newHPA)
+	}
+	return newHPA
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.OwnerReferences = ownerReferences
+	}
+}
+
+// WithScaleTargetDeployment sets the Deployment with the given name as the target to be scaled.
+func WithScaleTargetDeployment(name string) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.ScaleTargetRef = kautoscalingv2.CrossVersionObjectReference{
+			Kind:       "Deployment",
+			Name:       name,
+			APIVersion: "apps/v1",
+		}
+	}
+}
+
+func WithMinReplicas(replicas int32) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MinReplicas = utils.Int32Ptr(replicas)
+	}
+}
+
+func WithMaxReplicas(replicas int32) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MaxReplicas = replicas
+	}
+}
+
+// WithResourceUtilizationMetric adds a metric which scales based on the average utilization
+// of the given resource, e.g. CPU or memory.
+func WithResourceUtilizationMetric(resourceName kcorev1.ResourceName, averageUtilization int32) Opt {
+	return func(h *kautoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.Metrics = append(h.Spec.Metrics, kautoscalingv2.MetricSpec{
+			Type: kautoscalingv2.ResourceMetricSourceType,
+			Resource: &kautoscalingv2.ResourceMetricSource{
+				Name: resourceName,
+				Target: kautoscalingv2.MetricTarget{
+					Type:               kautoscalingv2.UtilizationMetricType,
+					AverageUtilization: utils.Int32Ptr(averageUtilization),
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package istio
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Istio resources are handled as unstructured objects, so that the Kyma companion manager does not depend
+// on Istio being installed.
+const (
+	networkingAPIVersion = "networking.istio.io/v1beta1"
+	securityAPIVersion   = "security.istio.io/v1beta1"
+
+	// SidecarInjectionLabel is the pod label which enables the injection of the Istio sidecar.
+	SidecarInjectionLabel = "sidecar.istio.io/inject"
+)
+
+// GroupVersionResources of the Istio resources, which are used to access them through the dynamic client.
+//
+//nolint:gochecknoglobals // used as constant.
+var (
+	VirtualServiceGVR = kschema.GroupVersionResource{
+		Group:    "networking.istio.io",
+		Version:  "v1beta1",
+		Resource: "virtualservices",
+	}
+	RequestAuthenticationGVR = kschema.GroupVersionResource{
+		Group:    "security.istio.io",
+		Version:  "v1beta1",
+		Resource: "requestauthentications",
+	}
+	AuthorizationPolicyGVR = kschema.GroupVersionResource{
+		Group:    "security.istio.io",
+		Version:  "v1beta1",
+		Resource: "authorizationpolicies",
+	}
+)
+
+type Opt func(object *kunstructured.Unstructured)
+
+func NewVirtualService(name, namespace string, opts ...Opt) *kunstructured.Unstructured {
+	return newObject(networkingAPIVersion, "VirtualService", name, namespace, opts...)
+}
+
+func NewRequestAuthentication(name, namespace string, opts ...Opt) *kunstructured.Unstructured {
+	return newObject(securityAPIVersion, "RequestAuthentication", name, namespace, opts...)
+}
+
+func NewAuthorizationPolicy(name, namespace string, opts ...Opt) *kunstructured.Unstructured {
+	return newObject(securityAPIVersion, "AuthorizationPolicy", name, namespace, opts...)
+}
+
+func newObject(apiVersion, kind, name, namespace string, opts ...Opt) *kunstructured.Unstructured {
+	newObj := &kunstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{},
+	}}
+	newObj.SetAPIVersion(apiVersion)
+	newObj.SetKind(kind)
+	newObj.SetName(name)
+	newObj.SetNamespace(namespace)
+	// apply options.
+	for _, o := range opts {
+		o(newObj)
+	}
+	return newObj
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		o.SetLabels(labels)
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(o *kunstructured.Unstructured) {
+		o.SetOwnerReferences(ownerReferences)
+	}
+}
+
+// WithHosts sets the hosts of a VirtualService.
+func WithHosts(hosts ...string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		spec(o)["hosts"] = toInterfaceSlice(hosts)
+	}
+}
+
+// WithGateways sets the gateways of a VirtualService in the format `<namespace>/<name>`.
+func WithGateways(gateways ...string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		spec(o)["gateways"] = toInterfaceSlice(gateways)
+	}
+}
+
+// WithHTTPRoute sets the route of a VirtualService, which forwards all HTTP requests to the given destination.
+func WithHTTPRoute(destinationHost string, port int32) Opt {
+	return func(o *kunstructured.Unstructured) {
+		spec(o)["http"] = []interface{}{
+			map[string]interface{}{
+				"route": []interface{}{
+					map[string]interface{}{
+						"destination": map[string]interface{}{
+							"host": destinationHost,
+							"port": map[string]interface{}{"number": int64(port)},
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+// WithWorkloadSelector sets the labels of the pods to which a RequestAuthentication or AuthorizationPolicy applies.
+func WithWorkloadSelector(labels map[string]string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		matchLabels := make(map[string]interface{}, len(labels))
+		for key, value := range labels {
+			matchLabels[key] = value
+		}
+		spec(o)["selector"] = map[string]interface{}{"matchLabels": matchLabels}
+	}
+}
+
+// WithJWTRule adds a rule to a RequestAuthentication, which validates the JSON Web Tokens of the given issuer.
+func WithJWTRule(issuer, jwksURI string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		rules, _ := spec(o)["jwtRules"].([]interface{})
+		spec(o)["jwtRules"] = append(rules, map[string]interface{}{
+			"issuer":  issuer,
+			"jwksUri": jwksURI,
+		})
+	}
+}
+
+// WithDenyWithoutRequestPrincipal makes an AuthorizationPolicy deny the requests to the given hosts which do not
+// have a valid JSON Web Token. Requests to other hosts, e.g. from inside the cluster, are not affected.
+func WithDenyWithoutRequestPrincipal(hosts ...string) Opt {
+	return func(o *kunstructured.Unstructured) {
+		spec(o)["action"] = "DENY"
+		spec(o)["rules"] = []interface{}{
+			map[string]interface{}{
+				"from": []interface{}{
+					map[string]interface{}{
+						"source": map[string]interface{}{"notRequestPrincipals": []interface{}{"*"}},
+					},
+				},
+				"to": []interface{}{
+					map[string]interface{}{
+						"operation": map[string]interface{}{"hosts": toInterfaceSlice(hosts)},
+					},
+				},
+			},
+		}
+	}
+}
+
+// spec returns the spec of the given object, which is created if it does not exist.
+func spec(o *kunstructured.Unstructured) map[string]interface{} {
+	s, ok := o.Object["spec"].(map[string]interface{})
+	if !ok {
+		s = map[string]interface{}{}
+		o.Object["spec"] = s
+	}
+	return s
+}
+
+// toInterfaceSlice converts the given strings, as unstructured objects only support slices of interfaces.
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
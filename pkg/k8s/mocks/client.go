@@ -8,9 +8,23 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 
+	corev1 "k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	mock "github.com/stretchr/testify/mock"
 
-	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	policyv1 "k8s.io/api/policy/v1"
+
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "k8s.io/api/rbac/v1"
+
+	v2 "k8s.io/api/autoscaling/v2"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -18,6 +32,24 @@ type Client struct {
 	mock.Mock
 }
 
+// ApplyUnstructured provides a mock function with given fields: ctx, resource, object
+func (_m *Client) ApplyUnstructured(ctx context.Context, resource schema.GroupVersionResource, object *unstructured.Unstructured) error {
+	ret := _m.Called(ctx, resource, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyUnstructured")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, schema.GroupVersionResource, *unstructured.Unstructured) error); ok {
+		r0 = rf(ctx, resource, object)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDeployment provides a mock function with given fields: ctx, name, namespace
 func (_m *Client) DeleteDeployment(ctx context.Context, name string, namespace string) error {
 	ret := _m.Called(ctx, name, namespace)
@@ -54,24 +86,102 @@ func (_m *Client) DeleteResource(ctx context.Context, object client.Object) erro
 	return r0
 }
 
+// DeleteUnstructured provides a mock function with given fields: ctx, resource, name, namespace
+func (_m *Client) DeleteUnstructured(ctx context.Context, resource schema.GroupVersionResource, name string, namespace string) error {
+	ret := _m.Called(ctx, resource, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnstructured")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, schema.GroupVersionResource, string, string) error); ok {
+		r0 = rf(ctx, resource, name, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClusterRole provides a mock function with given fields: ctx, name
+func (_m *Client) GetClusterRole(ctx context.Context, name string) (*v1.ClusterRole, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterRole")
+	}
+
+	var r0 *v1.ClusterRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1.ClusterRole, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1.ClusterRole); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ClusterRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterRoleBinding provides a mock function with given fields: ctx, name
+func (_m *Client) GetClusterRoleBinding(ctx context.Context, name string) (*v1.ClusterRoleBinding, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterRoleBinding")
+	}
+
+	var r0 *v1.ClusterRoleBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1.ClusterRoleBinding, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1.ClusterRoleBinding); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ClusterRoleBinding)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigMap provides a mock function with given fields: ctx, name, namespace
-func (_m *Client) GetConfigMap(ctx context.Context, name string, namespace string) (*v1.ConfigMap, error) {
+func (_m *Client) GetConfigMap(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
 	ret := _m.Called(ctx, name, namespace)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetConfigMap")
 	}
 
-	var r0 *v1.ConfigMap
+	var r0 *corev1.ConfigMap
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1.ConfigMap, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*corev1.ConfigMap, error)); ok {
 		return rf(ctx, name, namespace)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.ConfigMap); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *corev1.ConfigMap); ok {
 		r0 = rf(ctx, name, namespace)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.ConfigMap)
+			r0 = ret.Get(0).(*corev1.ConfigMap)
 		}
 	}
 
@@ -114,24 +224,174 @@ func (_m *Client) GetDeployment(ctx context.Context, name string, namespace stri
 	return r0, r1
 }
 
+// GetHorizontalPodAutoscaler provides a mock function with given fields: ctx, name, namespace
+func (_m *Client) GetHorizontalPodAutoscaler(ctx context.Context, name string, namespace string) (*v2.HorizontalPodAutoscaler, error) {
+	ret := _m.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHorizontalPodAutoscaler")
+	}
+
+	var r0 *v2.HorizontalPodAutoscaler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v2.HorizontalPodAutoscaler, error)); ok {
+		return rf(ctx, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v2.HorizontalPodAutoscaler); ok {
+		r0 = rf(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2.HorizontalPodAutoscaler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkPolicy provides a mock function with given fields: ctx, name, namespace
+func (_m *Client) GetNetworkPolicy(ctx context.Context, name string, namespace string) (*networkingv1.NetworkPolicy, error) {
+	ret := _m.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNetworkPolicy")
+	}
+
+	var r0 *networkingv1.NetworkPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*networkingv1.NetworkPolicy, error)); ok {
+		return rf(ctx, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *networkingv1.NetworkPolicy); ok {
+		r0 = rf(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*networkingv1.NetworkPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPodDisruptionBudget provides a mock function with given fields: ctx, name, namespace
+func (_m *Client) GetPodDisruptionBudget(ctx context.Context, name string, namespace string) (*policyv1.PodDisruptionBudget, error) {
+	ret := _m.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPodDisruptionBudget")
+	}
+
+	var r0 *policyv1.PodDisruptionBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*policyv1.PodDisruptionBudget, error)); ok {
+		return rf(ctx, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *policyv1.PodDisruptionBudget); ok {
+		r0 = rf(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*policyv1.PodDisruptionBudget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSecret provides a mock function with given fields: ctx, name, namespace
-func (_m *Client) GetSecret(ctx context.Context, name string, namespace string) (*v1.Secret, error) {
+func (_m *Client) GetSecret(ctx context.Context, name string, namespace string) (*corev1.Secret, error) {
 	ret := _m.Called(ctx, name, namespace)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSecret")
 	}
 
-	var r0 *v1.Secret
+	var r0 *corev1.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*corev1.Secret, error)); ok {
+		return rf(ctx, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *corev1.Secret); ok {
+		r0 = rf(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetService provides a mock function with given fields: ctx, name, namespace
+func (_m *Client) GetService(ctx context.Context, name string, namespace string) (*corev1.Service, error) {
+	ret := _m.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetService")
+	}
+
+	var r0 *corev1.Service
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*corev1.Service, error)); ok {
+		return rf(ctx, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *corev1.Service); ok {
+		r0 = rf(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Service)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceAccount provides a mock function with given fields: ctx, name, namespace
+func (_m *Client) GetServiceAccount(ctx context.Context, name string, namespace string) (*corev1.ServiceAccount, error) {
+	ret := _m.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccount")
+	}
+
+	var r0 *corev1.ServiceAccount
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1.Secret, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*corev1.ServiceAccount, error)); ok {
 		return rf(ctx, name, namespace)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Secret); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *corev1.ServiceAccount); ok {
 		r0 = rf(ctx, name, namespace)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Secret)
+			r0 = ret.Get(0).(*corev1.ServiceAccount)
 		}
 	}
 
@@ -144,6 +404,66 @@ func (_m *Client) GetSecret(ctx context.Context, name string, namespace string)
 	return r0, r1
 }
 
+// GetUnstructured provides a mock function with given fields: ctx, resource, name, namespace
+func (_m *Client) GetUnstructured(ctx context.Context, resource schema.GroupVersionResource, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _m.Called(ctx, resource, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnstructured")
+	}
+
+	var r0 *unstructured.Unstructured
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, schema.GroupVersionResource, string, string) (*unstructured.Unstructured, error)); ok {
+		return rf(ctx, resource, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, schema.GroupVersionResource, string, string) *unstructured.Unstructured); ok {
+		r0 = rf(ctx, resource, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, schema.GroupVersionResource, string, string) error); ok {
+		r1 = rf(ctx, resource, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPods provides a mock function with given fields: ctx, namespace, selector
+func (_m *Client) ListPods(ctx context.Context, namespace string, selector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	ret := _m.Called(ctx, namespace, selector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPods")
+	}
+
+	var r0 []corev1.Pod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *metav1.LabelSelector) ([]corev1.Pod, error)); ok {
+		return rf(ctx, namespace, selector)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *metav1.LabelSelector) []corev1.Pod); ok {
+		r0 = rf(ctx, namespace, selector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]corev1.Pod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *metav1.LabelSelector) error); ok {
+		r1 = rf(ctx, namespace, selector)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PatchApply provides a mock function with given fields: ctx, object
 func (_m *Client) PatchApply(ctx context.Context, object client.Object) error {
 	ret := _m.Called(ctx, object)
@@ -162,6 +482,64 @@ func (_m *Client) PatchApply(ctx context.Context, object client.Object) error {
 	return r0
 }
 
+// PatchApplyDryRun provides a mock function with given fields: ctx, object
+func (_m *Client) PatchApplyDryRun(ctx context.Context, object client.Object) (client.Object, error) {
+	ret := _m.Called(ctx, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchApplyDryRun")
+	}
+
+	var r0 client.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object) (client.Object, error)); ok {
+		return rf(ctx, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object) client.Object); ok {
+		r0 = rf(ctx, object)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.Object) error); ok {
+		r1 = rf(ctx, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceExists provides a mock function with given fields: ctx, object
+func (_m *Client) ResourceExists(ctx context.Context, object client.Object) (bool, error) {
+	ret := _m.Called(ctx, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object) (bool, error)); ok {
+		return rf(ctx, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object) bool); ok {
+		r0 = rf(ctx, object)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.Object) error); ok {
+		r1 = rf(ctx, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeployment provides a mock function with given fields: ctx, deployment
 func (_m *Client) UpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
 	ret := _m.Called(ctx, deployment)
@@ -0,0 +1,70 @@
+package networkpolicy
+
+import (
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Opt func(networkPolicy *knetworkingv1.NetworkPolicy)
+
+// NewNetworkPolicy returns a NetworkPolicy which restricts both the ingress and the egress of the selected pods.
+// Traffic is only allowed by the rules added with the given options.
+func NewNetworkPolicy(name, namespace string, opts ...Opt) *knetworkingv1.NetworkPolicy {
+	newNetworkPolicy := &knetworkingv1.NetworkPolicy{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: knetworkingv1.NetworkPolicySpec{
+			PolicyTypes: []knetworkingv1.PolicyType{
+				knetworkingv1.PolicyTypeIngress,
+				knetworkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newNetworkPolicy)
+	}
+	return newNetworkPolicy
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(n *knetworkingv1.NetworkPolicy) {
+		n.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(n *knetworkingv1.NetworkPolicy) {
+		n.OwnerReferences = ownerReferences
+	}
+}
+
+// WithPodSelector sets the labels of the pods to which the NetworkPolicy applies.
+func WithPodSelector(labels map[string]string) Opt {
+	return func(n *knetworkingv1.NetworkPolicy) {
+		n.Spec.PodSelector = kmetav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+// WithIngressRule allows ingress from the given peers to the given ports.
+func WithIngressRule(peers []knetworkingv1.NetworkPolicyPeer, ports []knetworkingv1.NetworkPolicyPort) Opt {
+	return func(n *knetworkingv1.NetworkPolicy) {
+		n.Spec.Ingress = append(n.Spec.Ingress, knetworkingv1.NetworkPolicyIngressRule{
+			From:  peers,
+			Ports: ports,
+		})
+	}
+}
+
+// WithEgressRules allows the egress defined by the given rules.
+func WithEgressRules(rules ...knetworkingv1.NetworkPolicyEgressRule) Opt {
+	return func(n *knetworkingv1.NetworkPolicy) {
+		n.Spec.Egress = append(n.Spec.Egress, rules...)
+	}
+}
@@ -0,0 +1,61 @@
+package pdb
+
+import (
+	kpolicyv1 "k8s.io/api/policy/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type Opt func(pdb *kpolicyv1.PodDisruptionBudget)
+
+func NewPDB(name, namespace string, opts ...Opt) *kpolicyv1.PodDisruptionBudget {
+	newPDB := &kpolicyv1.PodDisruptionBudget{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kpolicyv1.PodDisruptionBudgetSpec{},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newPDB)
+	}
+	return newPDB
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(p *kpolicyv1.PodDisruptionBudget) {
+		p.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(p *kpolicyv1.PodDisruptionBudget) {
+		p.OwnerReferences = ownerReferences
+	}
+}
+
+// WithSelector sets the labels of the pods which are protected by the PodDisruptionBudget.
+func WithSelector(labels map[string]string) Opt {
+	return func(p *kpolicyv1.PodDisruptionBudget) {
+		p.Spec.Selector = kmetav1.SetAsLabelSelector(labels)
+	}
+}
+
+func WithMinAvailable(minAvailable intstr.IntOrString) Opt {
+	return func(p *kpolicyv1.PodDisruptionBudget) {
+		p.Spec.MinAvailable = &minAvailable
+		p.Spec.MaxUnavailable = nil
+	}
+}
+
+func WithMaxUnavailable(maxUnavailable intstr.IntOrString) Opt {
+	return func(p *kpolicyv1.PodDisruptionBudget) {
+		p.Spec.MaxUnavailable = &maxUnavailable
+		p.Spec.MinAvailable = nil
+	}
+}
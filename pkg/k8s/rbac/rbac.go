@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	krbacv1 "k8s.io/api/rbac/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ClusterRoleOpt func(clusterRole *krbacv1.ClusterRole)
+
+type ClusterRoleBindingOpt func(clusterRoleBinding *krbacv1.ClusterRoleBinding)
+
+func NewClusterRole(name string, opts ...ClusterRoleOpt) *krbacv1.ClusterRole {
+	newClusterRole := &krbacv1.ClusterRole{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "ClusterRole",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newClusterRole)
+	}
+	return newClusterRole
+}
+
+func WithClusterRoleLabels(labels map[string]string) ClusterRoleOpt {
+	return func(c *krbacv1.ClusterRole) {
+		c.ObjectMeta.Labels = labels
+	}
+}
+
+// WithRule adds a rule which grants the given verbs on the given resources.
+func WithRule(apiGroups, resources, verbs []string) ClusterRoleOpt {
+	return func(c *krbacv1.ClusterRole) {
+		c.Rules = append(c.Rules, krbacv1.PolicyRule{
+			APIGroups: apiGroups,
+			Resources: resources,
+			Verbs:     verbs,
+		})
+	}
+}
+
+func NewClusterRoleBinding(name string, opts ...ClusterRoleBindingOpt) *krbacv1.ClusterRoleBinding {
+	newClusterRoleBinding := &krbacv1.ClusterRoleBinding{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newClusterRoleBinding)
+	}
+	return newClusterRoleBinding
+}
+
+func WithClusterRoleBindingLabels(labels map[string]string) ClusterRoleBindingOpt {
+	return func(c *krbacv1.ClusterRoleBinding) {
+		c.ObjectMeta.Labels = labels
+	}
+}
+
+// WithClusterRoleRef sets the ClusterRole with the given name as the role which is bound.
+func WithClusterRoleRef(name string) ClusterRoleBindingOpt {
+	return func(c *krbacv1.ClusterRoleBinding) {
+		c.RoleRef = krbacv1.RoleRef{
+			APIGroup: krbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		}
+	}
+}
+
+// WithServiceAccountSubject adds the ServiceAccount with the given name and namespace as subject.
+func WithServiceAccountSubject(name, namespace string) ClusterRoleBindingOpt {
+	return func(c *krbacv1.ClusterRoleBinding) {
+		c.Subjects = append(c.Subjects, krbacv1.Subject{
+			Kind:      krbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: namespace,
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type Opt func(service *kcorev1.Service)
+
+func NewService(name, namespace string, opts ...Opt) *kcorev1.Service {
+	newService := &kcorev1.Service{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kcorev1.ServiceSpec{
+			Type: kcorev1.ServiceTypeClusterIP,
+		},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newService)
+	}
+	return newService
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(s *kcorev1.Service) {
+		s.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(s *kcorev1.Service) {
+		s.OwnerReferences = ownerReferences
+	}
+}
+
+func WithSelector(selector map[string]string) Opt {
+	return func(s *kcorev1.Service) {
+		s.Spec.Selector = selector
+	}
+}
+
+// WithPort adds a TCP port to the service which targets the named port of the selected pods.
+func WithPort(name string, port int32, targetPortName string) Opt {
+	return func(s *kcorev1.Service) {
+		s.Spec.Ports = append(s.Spec.Ports, kcorev1.ServicePort{
+			Name:       name,
+			Protocol:   kcorev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromString(targetPortName),
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package serviceaccount
+
+import (
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Opt func(serviceAccount *kcorev1.ServiceAccount)
+
+func NewServiceAccount(name, namespace string, opts ...Opt) *kcorev1.ServiceAccount {
+	newServiceAccount := &kcorev1.ServiceAccount{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	// apply options.
+	for _, o := range opts {
+		o(newServiceAccount)
+	}
+	return newServiceAccount
+}
+
+func WithLabels(labels map[string]string) Opt {
+	return func(s *kcorev1.ServiceAccount) {
+		s.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReferences(ownerReferences []kmetav1.OwnerReference) Opt {
+	return func(s *kcorev1.ServiceAccount) {
+		s.OwnerReferences = ownerReferences
+	}
+}
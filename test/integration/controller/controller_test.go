@@ -24,7 +24,7 @@ func TestMain(m *testing.M) {
 
 	// setup env test
 	var err error
-	testEnvironment, err = integration.NewTestEnvironment(projectRootDir)
+	testEnvironment, err = integration.NewTestEnvironment(projectRootDir, false)
 	if err != nil {
 		log.Fatal(err)
 	}
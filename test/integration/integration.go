@@ -2,8 +2,11 @@ package integration
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
+	"net"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -23,12 +26,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	kctrllogzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	kctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	kcmv1beta1 "github.com/kyma-project/kyma-companion-manager/api/v1beta1"
 	"github.com/kyma-project/kyma-companion-manager/internal/backendmanager"
 	kcmctrl "github.com/kyma-project/kyma-companion-manager/internal/controller"
+	kcmmetrics "github.com/kyma-project/kyma-companion-manager/internal/metrics"
 	"github.com/kyma-project/kyma-companion-manager/pkg/env"
 	kcmk8s "github.com/kyma-project/kyma-companion-manager/pkg/k8s"
 	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
@@ -58,8 +64,11 @@ type TestEnvironment struct {
 	TestCancelFn     context.CancelFunc
 }
 
+// NewTestEnvironment starts an envtest environment with the Companion controller and the conversion webhook of
+// the Companion CR. If enableWebhook is true, the admission webhooks of the Companion CR are registered as well.
+//
 //nolint:funlen // Used in testing
-func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
+func NewTestEnvironment(projectRootDir string, enableWebhook bool) (*TestEnvironment, error) {
 	var err error
 
 	// setup logger
@@ -69,13 +78,16 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 	}
 	kctrl.SetLogger(kctrllogzap.New())
 
-	testEnv, envTestKubeCfg, err := StartEnvTest(projectRootDir)
-	if err != nil {
+	// add to Scheme. Both versions must be registered before envtest is started, so that it configures the
+	// conversion webhook of the Companion CRD.
+	if err = kcmv1alpha1.AddToScheme(kkubernetesscheme.Scheme); err != nil {
+		return nil, err
+	}
+	if err = kcmv1beta1.AddToScheme(kkubernetesscheme.Scheme); err != nil {
 		return nil, err
 	}
 
-	// add to Scheme
-	err = kcmv1alpha1.AddToScheme(kkubernetesscheme.Scheme)
+	testEnv, envTestKubeCfg, err := StartEnvTest(projectRootDir, enableWebhook)
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +109,12 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 		return nil, err
 	}
 
-	// setup ctrl manager
-	metricsPort, err := testutils.GetFreePort()
-	if err != nil {
-		return nil, err
+	// setup ctrl manager. The webhook server always serves the conversion webhook of the Companion CRD.
+	webhookInstallOptions := testEnv.WebhookInstallOptions
+	webhookServerOptions := webhook.Options{
+		Host:    webhookInstallOptions.LocalServingHost,
+		Port:    webhookInstallOptions.LocalServingPort,
+		CertDir: webhookInstallOptions.LocalServingCertDir,
 	}
 
 	ctrlMgr, err := kctrl.NewManager(envTestKubeCfg, kctrl.Options{
@@ -108,14 +122,14 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 		HealthProbeBindAddress: "0",                              // disable
 		PprofBindAddress:       "0",                              // disable
 		Metrics:                server.Options{BindAddress: "0"}, // disable
-		WebhookServer:          webhook.NewServer(webhook.Options{Port: metricsPort}),
+		WebhookServer:          webhook.NewServer(webhookServerOptions),
 	})
 	if err != nil {
 		return nil, err
 	}
 	recorder := ctrlMgr.GetEventRecorderFor("kyma-companion-manager")
 
-	kubeClient := kcmk8s.NewKubeClient(ctrlMgr.GetClient(), "kyma-companion-manager", dynamicClient)
+	kubeClient := kcmk8s.NewKubeClient(ctrlMgr.GetClient(), kcmk8s.FieldManager, dynamicClient)
 
 	backendManager := backendmanager.NewBackendManager(
 		k8sClient,
@@ -123,6 +137,11 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 		sugaredLogger,
 	)
 
+	metricsCollector := kcmmetrics.NewCollector()
+	if err = metricsCollector.RegisterMetrics(kctrlmetrics.Registry); err != nil {
+		return nil, err
+	}
+
 	// setup reconciler
 	kcmReconciler := kcmctrl.NewReconciler(
 		ctrlMgr.GetClient(),
@@ -130,11 +149,21 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 		backendManager,
 		ctrlMgr.GetScheme(),
 		sugaredLogger,
+		recorder,
+		metricsCollector,
 		configs,
 	)
 	if err = (kcmReconciler).SetupWithManager(ctrlMgr); err != nil {
 		return nil, err
 	}
+	if enableWebhook {
+		if err = (&kcmv1alpha1.Companion{}).SetupWebhookWithManager(ctrlMgr); err != nil {
+			return nil, err
+		}
+	}
+	if err = (&kcmv1beta1.Companion{}).SetupWebhookWithManager(ctrlMgr); err != nil {
+		return nil, err
+	}
 
 	// start manager
 	var cancelCtx context.CancelFunc
@@ -147,6 +176,10 @@ func NewTestEnvironment(projectRootDir string) (*TestEnvironment, error) {
 		}
 	}()
 
+	if err = waitForWebhookServer(testEnv.WebhookInstallOptions); err != nil {
+		return nil, err
+	}
+
 	return &TestEnvironment{
 		k8sClient:        k8sClient,
 		K8sDynamicClient: dynamicClient,
@@ -180,6 +213,10 @@ func (env TestEnvironment) CreateK8sResource(ctx context.Context, obj client.Obj
 	return env.k8sClient.Create(ctx, obj)
 }
 
+func (env TestEnvironment) UpdateK8sResource(ctx context.Context, obj client.Object) error {
+	return env.k8sClient.Update(ctx, obj)
+}
+
 func (env TestEnvironment) EnsureNamespaceCreation(t *testing.T, ctx context.Context, namespace string) {
 	t.Helper()
 	if namespace == "default" {
@@ -264,6 +301,14 @@ func (env TestEnvironment) EnsureK8sServiceNotFound(t *testing.T, ctx context.Co
 	}, SmallTimeOut, SmallPollingInterval, "failed to ensure non-existence of Service")
 }
 
+func (env TestEnvironment) EnsureCompanionCRNotFound(t *testing.T, ctx context.Context, name, namespace string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		_, err := env.GetCompanionCRFromK8s(ctx, name, namespace)
+		return err != nil && kapierrors.IsNotFound(err)
+	}, BigTimeOut, BigPollingInterval, "failed to ensure non-existence of Companion CR")
+}
+
 func (env TestEnvironment) GetConfigMapFromK8s(ctx context.Context,
 	name, namespace string,
 ) (*kcorev1.ConfigMap, error) {
@@ -340,7 +385,7 @@ func (env TestEnvironment) DeleteSecretFromK8s(ctx context.Context, name, namesp
 	})
 }
 
-func StartEnvTest(projectRootDir string) (*envtest.Environment, *rest.Config, error) {
+func StartEnvTest(projectRootDir string, enableWebhook bool) (*envtest.Environment, *rest.Config, error) {
 	// Reference: https://book.kubebuilder.io/reference/envtest.html
 	useExistingCluster := useExistingCluster
 	testEnv := &envtest.Environment{
@@ -351,6 +396,13 @@ func StartEnvTest(projectRootDir string) (*envtest.Environment, *rest.Config, er
 		AttachControlPlaneOutput: attachControlPlaneOutput,
 		UseExistingCluster:       &useExistingCluster,
 	}
+	// the local webhook server is always configured, because envtest points the conversion webhook of the
+	// Companion CRD to it. The admission webhooks are only installed if enableWebhook is true.
+	if enableWebhook {
+		testEnv.WebhookInstallOptions = envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join(projectRootDir, "config", "webhook")},
+		}
+	}
 
 	var cfg *rest.Config
 	err := retry.Do(func() error {
@@ -375,3 +427,21 @@ func StartEnvTest(projectRootDir string) (*envtest.Environment, *rest.Config, er
 	)
 	return testEnv, cfg, err
 }
+
+// waitForWebhookServer waits until the webhook server serves TLS connections.
+func waitForWebhookServer(options envtest.WebhookInstallOptions) error {
+	address := net.JoinHostPort(options.LocalServingHost, strconv.Itoa(options.LocalServingPort))
+	dialer := &net.Dialer{Timeout: time.Second}
+	return retry.Do(func() error {
+		//nolint:gosec // the webhook server uses a self-signed certificate in tests.
+		conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	},
+		retry.Delay(SmallPollingInterval),
+		retry.DelayType(retry.FixedDelay),
+		retry.Attempts(uint(SmallTimeOut/SmallPollingInterval)),
+	)
+}
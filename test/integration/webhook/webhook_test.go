@@ -0,0 +1,183 @@
+package webhook_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
+	"github.com/kyma-project/kyma-companion-manager/test/integration"
+	testutils "github.com/kyma-project/kyma-companion-manager/test/utils"
+)
+
+const projectRootDir = "../../../"
+
+var testEnvironment *integration.TestEnvironment
+
+// TestMain pre-hook and post-hook to run before and after all tests.
+func TestMain(m *testing.M) {
+	// Note: The tests in this package must not run in parallel,
+	// because only a single Companion CR is allowed in the cluster.
+
+	// setup env test with webhooks
+	var err error
+	testEnvironment, err = integration.NewTestEnvironment(projectRootDir, true)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// run tests
+	code := m.Run()
+
+	// tear down test env
+	if err = testEnvironment.TearDown(); err != nil {
+		log.Fatal(err)
+	}
+
+	os.Exit(code)
+}
+
+func Test_RejectInvalidCompanionCR(t *testing.T) {
+	testCases := []struct {
+		name           string
+		givenCompanion *kcmv1alpha1.Companion
+		wantErrorField string
+	}{
+		{
+			name:           "should reject min replicas greater than max replicas",
+			givenCompanion: testutils.NewCompanionCR(testutils.WithReplicas(4, 2)),
+			wantErrorField: "spec.companion.replicas.min",
+		},
+		{
+			name:           "should reject an empty secret name",
+			givenCompanion: testutils.NewCompanionCR(testutils.WithRedisSecret("", "redis")),
+			wantErrorField: "spec.redis.secret.name",
+		},
+		{
+			name:           "should reject an empty secret namespace",
+			givenCompanion: testutils.NewCompanionCR(testutils.WithAICoreSecret("ai-core", "")),
+			wantErrorField: "spec.aicore.secret.namespace",
+		},
+		{
+			name: "should reject resource requests larger than limits",
+			givenCompanion: testutils.NewCompanionCR(testutils.WithResources(kcorev1.ResourceRequirements{
+				Requests: kcorev1.ResourceList{kcorev1.ResourceMemory: resource.MustParse("8Gi")},
+				Limits:   kcorev1.ResourceList{kcorev1.ResourceMemory: resource.MustParse("4Gi")},
+			})),
+			wantErrorField: "spec.companion.resources.requests[memory]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// given
+			testEnvironment.EnsureNamespaceCreation(t, ctx, tc.givenCompanion.GetNamespace())
+
+			// when
+			err := testEnvironment.CreateK8sResource(ctx, tc.givenCompanion)
+
+			// then
+			require.Error(t, err)
+			require.True(t, kapierrors.IsInvalid(err), "expected an Invalid error but got: %v", err)
+			require.Contains(t, err.Error(), tc.wantErrorField)
+		})
+	}
+}
+
+func Test_RejectSecondCompanionCR(t *testing.T) {
+	ctx := context.Background()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	testEnvironment.EnsureNamespaceCreation(t, ctx, givenCompanion.GetNamespace())
+	testEnvironment.EnsureK8sResourceCreated(t, ctx, givenCompanion)
+	defer func() {
+		testEnvironment.EnsureK8sResourceDeleted(t, ctx, givenCompanion)
+		testEnvironment.EnsureCompanionCRNotFound(t, ctx, givenCompanion.GetName(), givenCompanion.GetNamespace())
+	}()
+
+	secondCompanion := testutils.NewCompanionCR()
+	testEnvironment.EnsureNamespaceCreation(t, ctx, secondCompanion.GetNamespace())
+
+	// when
+	err := testEnvironment.CreateK8sResource(ctx, secondCompanion)
+
+	// then
+	require.Error(t, err)
+	require.True(t, kapierrors.IsForbidden(err), "expected a Forbidden error but got: %v", err)
+}
+
+func Test_RejectInvalidCompanionCRUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	testEnvironment.EnsureNamespaceCreation(t, ctx, givenCompanion.GetNamespace())
+	testEnvironment.EnsureK8sResourceCreated(t, ctx, givenCompanion)
+	defer func() {
+		testEnvironment.EnsureK8sResourceDeleted(t, ctx, givenCompanion)
+		testEnvironment.EnsureCompanionCRNotFound(t, ctx, givenCompanion.GetName(), givenCompanion.GetNamespace())
+	}()
+
+	latestCompanion, err := testEnvironment.GetCompanionCRFromK8s(ctx, givenCompanion.GetName(),
+		givenCompanion.GetNamespace())
+	require.NoError(t, err)
+
+	// when
+	latestCompanion.Spec.Companion.Replicas = kcmv1alpha1.ReplicasConfig{Min: 5, Max: 1}
+	err = testEnvironment.UpdateK8sResource(ctx, &latestCompanion)
+
+	// then
+	require.Error(t, err)
+	require.True(t, kapierrors.IsInvalid(err), "expected an Invalid error but got: %v", err)
+}
+
+func Test_AllowFinalizerRemovalFromInvalidCompanionCR(t *testing.T) {
+	ctx := context.Background()
+	const testFinalizer = "test.kyma-project.io/finalizer"
+
+	// given
+	givenCompanion := testutils.NewCompanionCR()
+	givenCompanion.SetFinalizers([]string{testFinalizer})
+	testEnvironment.EnsureNamespaceCreation(t, ctx, givenCompanion.GetNamespace())
+	testEnvironment.EnsureK8sResourceCreated(t, ctx, givenCompanion)
+	testEnvironment.EnsureK8sResourceDeleted(t, ctx, givenCompanion)
+
+	// the spec of a Companion CR in deletion is not validated, so the CR can be made invalid.
+	updateCompanionCR(t, ctx, givenCompanion, func(companion *kcmv1alpha1.Companion) {
+		companion.Spec.Companion.Replicas = kcmv1alpha1.ReplicasConfig{Min: 5, Max: 1}
+	})
+
+	// when
+	updateCompanionCR(t, ctx, givenCompanion, func(companion *kcmv1alpha1.Companion) {
+		companion.SetFinalizers(nil)
+	})
+
+	// then
+	testEnvironment.EnsureCompanionCRNotFound(t, ctx, givenCompanion.GetName(), givenCompanion.GetNamespace())
+}
+
+// updateCompanionCR applies the given modifier to the latest version of the given Companion CR and updates it.
+// The update is retried, e.g. on conflicts with the controller.
+func updateCompanionCR(t *testing.T, ctx context.Context, givenCompanion *kcmv1alpha1.Companion,
+	modifier func(companion *kcmv1alpha1.Companion),
+) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		latestCompanion, err := testEnvironment.GetCompanionCRFromK8s(ctx, givenCompanion.GetName(),
+			givenCompanion.GetNamespace())
+		if err != nil {
+			return false
+		}
+		modifier(&latestCompanion)
+		return testEnvironment.UpdateK8sResource(ctx, &latestCompanion) == nil
+	}, integration.SmallTimeOut, integration.SmallPollingInterval, "failed to update Companion CR")
+}
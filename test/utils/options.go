@@ -1,6 +1,7 @@
 package utils
 
 import (
+	kcorev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kcmv1alpha1 "github.com/kyma-project/kyma-companion-manager/api/v1alpha1"
@@ -14,3 +15,53 @@ func WithCompanionCRFinalizer(finalizer string) CompanionOption {
 		return nil
 	}
 }
+
+func WithAICoreSecret(name, namespace string) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.AICore.Secret = kcmv1alpha1.SecretSpec{Name: name, Namespace: namespace}
+		return nil
+	}
+}
+
+func WithHanaCloudSecret(name, namespace string) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.HanaCloud.Secret = kcmv1alpha1.SecretSpec{Name: name, Namespace: namespace}
+		return nil
+	}
+}
+
+func WithRedisSecret(name, namespace string) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.Redis.Secret = kcmv1alpha1.SecretSpec{Name: name, Namespace: namespace}
+		return nil
+	}
+}
+
+func WithReplicas(minReplicas, maxReplicas int) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.Companion.Replicas.Min = minReplicas
+		c.Spec.Companion.Replicas.Max = maxReplicas
+		return nil
+	}
+}
+
+func WithResources(resources kcorev1.ResourceRequirements) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.Companion.Resources = resources
+		return nil
+	}
+}
+
+func WithImage(image *kcmv1alpha1.ImageConfig) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.Spec.Companion.Image = image
+		return nil
+	}
+}
+
+func WithAnnotations(annotations map[string]string) CompanionOption {
+	return func(c *kcmv1alpha1.Companion) error {
+		c.SetAnnotations(annotations)
+		return nil
+	}
+}
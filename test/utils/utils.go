@@ -244,7 +244,15 @@ func NewCompanionCR(opts ...CompanionOption) *kcmv1alpha1.Companion {
 			Namespace: namespace,
 			UID:       "1234-5678-1234-5678",
 		},
-		Spec: kcmv1alpha1.CompanionSpec{},
+		Spec: kcmv1alpha1.CompanionSpec{
+			AICore:    kcmv1alpha1.AICoreConfig{Secret: kcmv1alpha1.SecretSpec{Name: "ai-core", Namespace: "ai-core"}},
+			HanaCloud: kcmv1alpha1.HanaConfig{Secret: kcmv1alpha1.SecretSpec{Name: "companion", Namespace: "hana-cloud"}},
+			Redis:     kcmv1alpha1.RedisConfig{Secret: kcmv1alpha1.SecretSpec{Name: "companion", Namespace: "redis"}},
+			Companion: kcmv1alpha1.CompanionConfig{
+				Secret:   kcmv1alpha1.SecretSpec{Name: "companion", Namespace: "ai-core"},
+				Replicas: kcmv1alpha1.ReplicasConfig{Min: 1, Max: 3},
+			},
+		},
 	}
 
 	for _, opt := range opts {